@@ -6,6 +6,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 type CellState string
@@ -47,6 +48,8 @@ type Cell struct {
 	Char        rune
 	Coordinates [2]int
 	Bonus       CellBonusType
+	// IsBlank is true if the letter was played using a blank tile.
+	IsBlank bool
 }
 
 func (c Cell) Empty() bool {
@@ -61,8 +64,16 @@ func (c Cell) IndexString() string {
 	return fmt.Sprintf("%d", c.Index)
 }
 
+// LetterScore is the face value of the tile, blanks are always worth zero.
+func (c Cell) LetterScore() int {
+	if c.IsBlank {
+		return 0
+	}
+	return LetterScores[c.Char]
+}
+
 func (c Cell) LetterScoreString() string {
-	return fmt.Sprintf("%d", LetterScores[c.Char])
+	return fmt.Sprintf("%d", c.LetterScore())
 }
 
 type Neighbours struct {
@@ -117,6 +128,11 @@ func (b Board) isValidWordPlacement(placement Placement, word string, firstWord
 
 	for i, letter := range []rune(word) {
 		isOverlapping := false
+		isBlank := unicode.IsLower(letter)
+		letter = unicode.ToUpper(letter)
+		if _, ok := LetterScores[letter]; !ok || letter == blankLetter {
+			return nil, fmt.Errorf("word contains invalid letter: %s", string(letter))
+		}
 
 		cellIndex := b.getCellIndex(placement, i)
 		if cellIndex == -1 {
@@ -137,6 +153,10 @@ func (b Board) isValidWordPlacement(placement Placement, word string, firstWord
 			// letter already exits
 			overlaps++
 			isOverlapping = true
+			isBlank = cell.IsBlank
+		} else if isBlank {
+			// user must have a blank
+			result.LettersSpent = append(result.LettersSpent, blankLetter)
 		} else {
 			// user must have letter
 			result.LettersSpent = append(result.LettersSpent, letter)
@@ -146,6 +166,7 @@ func (b Board) isValidWordPlacement(placement Placement, word string, firstWord
 			Index:       cell.Index,
 			Char:        letter,
 			Coordinates: cell.Coordinates,
+			IsBlank:     isBlank,
 		}
 		// the bonus is only valid for the original letter on that square
 		if !isOverlapping {
@@ -181,7 +202,7 @@ func (b Board) isValidWordPlacement(placement Placement, word string, firstWord
 			if placement.Direction != Across {
 				touchingX := make([]Cell, 0)
 				if neighbours.L {
-					lhs := b.NeighboringWord(int64(cell.Index), L)
+					lhs := withoutBonuses(b.NeighboringWord(int64(cell.Index), L))
 					slices.Reverse(lhs)
 					touchingX = append(touchingX, lhs...)
 				}
@@ -193,7 +214,7 @@ func (b Board) isValidWordPlacement(placement Placement, word string, firstWord
 					}
 				}
 				if neighbours.R {
-					touchingX = append(touchingX, b.NeighboringWord(int64(cell.Index), R)...)
+					touchingX = append(touchingX, withoutBonuses(b.NeighboringWord(int64(cell.Index), R))...)
 				}
 				if len(touchingX) > 0 {
 					result.Touching = append(result.Touching, touchingX)
@@ -205,15 +226,15 @@ func (b Board) isValidWordPlacement(placement Placement, word string, firstWord
 			if placement.Direction != Down {
 				touchingY := make([]Cell, 0)
 				if neighbours.A {
-					lhs := b.NeighboringWord(int64(cell.Index), U)
+					lhs := withoutBonuses(b.NeighboringWord(int64(cell.Index), U))
 					slices.Reverse(lhs)
-					touchingY = append(lhs, *cell)
+					touchingY = append(touchingY, lhs...)
 				}
 				if neighbours.A || neighbours.B {
 					touchingY = append(touchingY, thisCell)
 				}
 				if neighbours.B {
-					touchingY = append(touchingY, b.NeighboringWord(int64(cell.Index), D)...)
+					touchingY = append(touchingY, withoutBonuses(b.NeighboringWord(int64(cell.Index), D))...)
 				}
 				if len(touchingY) > 0 {
 					result.Touching = append(result.Touching, touchingY)
@@ -257,13 +278,26 @@ func (b Board) placeWord(placement Placement, word string) (*PlacementResult, er
 		result.Cells = append(result.Cells, cell)
 
 		if placed {
-			result.LettersSpent = append(result.LettersSpent, letter)
+			if cell.IsBlank {
+				result.LettersSpent = append(result.LettersSpent, blankLetter)
+			} else {
+				result.LettersSpent = append(result.LettersSpent, cell.Char)
+			}
 		}
 
 	}
 	return result, nil
 }
 
+// withoutBonuses removes the bonus from cells that were already on the board, bonuses are only valid for the
+// turn a tile is placed on them.
+func withoutBonuses(cells []Cell) []Cell {
+	for i := range cells {
+		cells[i].Bonus = NoBonusType
+	}
+	return cells
+}
+
 func (b Board) GetCell(cellID int64, state CellState) *Cell {
 	// cells indexes start at 1
 	if cellID < 1 {
@@ -311,6 +345,7 @@ func (b Board) NeighboringWord(cellID int64, direction Direction) []Cell {
 	}
 }
 
+// SetCell places the letter in the cell if it is empty. A lower case letter is placed as a blank tile.
 func (b Board) SetCell(cellID int64, letter rune) (Cell, bool) {
 	curID := int64(0)
 	set := false
@@ -321,8 +356,9 @@ func (b Board) SetCell(cellID int64, letter rune) (Cell, bool) {
 			if curID == cellID {
 				if b[rowIdx][colIdx].Empty() {
 					set = true
+					b[rowIdx][colIdx].Char = unicode.ToUpper(letter)
+					b[rowIdx][colIdx].IsBlank = unicode.IsLower(letter)
 				}
-				b[rowIdx][colIdx].Char = letter
 				cell = b[rowIdx][colIdx]
 			}
 		}
@@ -345,6 +381,17 @@ func (b Board) getCenterCellIdx() int64 {
 	return int64(middle + (size * math.Floor(size/float64(2))))
 }
 
+func (b Board) clone() Board {
+	out := make(Board, len(b))
+	for i, row := range b {
+		out[i] = slices.Clone(row)
+	}
+	return out
+}
+
+// StandardBoardSize is the width and height of a standard board.
+const StandardBoardSize = 15
+
 type InitialWord struct {
 	Placement Placement
 	Word      string
@@ -385,6 +432,19 @@ func (p Placement) String() string {
 	return fmt.Sprintf("%s%d", p.Direction, p.CellId)
 }
 
+func (p Placement) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Placement) UnmarshalText(text []byte) error {
+	place, err := ParsePlacement(string(text))
+	if err != nil {
+		return err
+	}
+	*p = place
+	return nil
+}
+
 func MustParsePlacement(placementStr string) Placement {
 	place, err := ParsePlacement(placementStr)
 	if err != nil {
@@ -393,6 +453,8 @@ func MustParsePlacement(placementStr string) Placement {
 	return place
 }
 
+// ParsePlacement parses a cell index placement e.g. "A113" or "D57" as written by Placement.String. Standard coordinate
+// notation e.g. "8H" is parsed by ParseCoordinate.
 func ParsePlacement(placementStr string) (Placement, error) {
 	p := Placement{}
 	if strings.HasPrefix(placementStr, "D") {
@@ -412,12 +474,73 @@ func ParsePlacement(placementStr string) (Placement, error) {
 	return p, nil
 }
 
+// Coordinate returns the placement in standard notation on a StandardBoardSize board. Across words are written
+// row then column e.g. "8H" and down words column then row e.g. "H8". An empty string is returned if the
+// placement is not on the board.
+func (p Placement) Coordinate() string {
+	coordinate, err := p.coordinate(StandardBoardSize)
+	if err != nil {
+		return ""
+	}
+	return coordinate
+}
+
+func (p Placement) coordinate(size int) (string, error) {
+	if p.CellId < 1 || p.CellId > int64(size*size) {
+		return "", fmt.Errorf("placement is not on the board: %s", p.String())
+	}
+	row := int(p.CellId-1) / size
+	col := int(p.CellId-1) % size
+	if p.Direction == Down {
+		return fmt.Sprintf("%c%d", 'A'+col, row+1), nil
+	}
+	return fmt.Sprintf("%d%c", row+1, 'A'+col), nil
+}
+
+// ParseCoordinate parses a placement in standard notation on a StandardBoardSize board. A row number followed by
+// a column letter e.g. "8H" is an across word and a column letter followed by row number e.g. "H8" is a down word.
+func ParseCoordinate(coordinate string) (Placement, error) {
+	return parseCoordinate(StandardBoardSize, coordinate)
+}
+
+func parseCoordinate(size int, coordinate string) (Placement, error) {
+	coordinate = strings.ToUpper(coordinate)
+	if coordinate == "" {
+		return Placement{}, fmt.Errorf("empty coordinate")
+	}
+	direction := Across
+	colStr, rowStr := strings.TrimLeftFunc(coordinate, unicode.IsDigit), strings.TrimRightFunc(coordinate, unicode.IsLetter)
+	if unicode.IsLetter(rune(coordinate[0])) {
+		direction = Down
+		rowStr, colStr = strings.TrimLeftFunc(coordinate, unicode.IsLetter), strings.TrimRightFunc(coordinate, unicode.IsDigit)
+	}
+	row, err := strconv.Atoi(rowStr)
+	if err != nil || len(colStr) != 1 {
+		return Placement{}, fmt.Errorf("invalid coordinate: %s", coordinate)
+	}
+	col := int(colStr[0] - 'A')
+	if row < 1 || row > size || col < 0 || col >= size {
+		return Placement{}, fmt.Errorf("coordinate is not on the board: %s", coordinate)
+	}
+	return Placement{CellId: int64((row-1)*size + col + 1), Direction: direction}, nil
+}
+
 type PlacementResult struct {
 	Cells        []Cell
 	LettersSpent []rune
 	Touching     [][]Cell
 }
 
+// Words returns the main word followed by any words formed by touching existing tiles.
+func (r *PlacementResult) Words() []string {
+	words := []string{cellsToWord(r.Cells)}
+	for _, v := range r.Touching {
+		words = append(words, cellsToWord(v))
+	}
+	return words
+}
+
+// ExplainScore describes how each word in the placement was scored e.g. "CAT: C (3) A (1x2) T (1) [x2] = 12".
 func (r *PlacementResult) ExplainScore() []string {
 	_, e := r.score()
 	wordExplanations := []string{}
@@ -432,6 +555,11 @@ func (r *PlacementResult) Score() int {
 	return s
 }
 
+// BingoBonus is awarded for using all of a player's letters in one turn.
+const BingoBonus = 50
+
+// score totals the main word and every cross word. Letter and word bonuses only apply to tiles placed this turn,
+// cells that were already on the board have their bonus removed when the placement is checked.
 func (r *PlacementResult) score() (int, [][]string) {
 	total := 0
 	words := [][]Cell{r.Cells}
@@ -444,50 +572,45 @@ func (r *PlacementResult) score() (int, [][]string) {
 		var wordTotal int
 		var wordBonuses []CellBonusType
 
-		// only the word can score bonuses, not the touching words
-		allowBonuses := false
-		if wordIdx == 0 {
-			allowBonuses = true
-		}
-
+		explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("%s:", cellsToWord(word)))
 		for _, c := range word {
-			letterScore := LetterScores[c.Char]
-			if allowBonuses {
-				switch c.Bonus {
-				case NoBonusType:
-					wordTotal += letterScore
-					explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("%s (%d)", string(c.Char), letterScore))
-				case DoubleLetterScoreType:
-					wordTotal += letterScore * 2
-					explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("%s (%dx2)", string(c.Char), letterScore))
-				case TripleLetterScoreType:
-					wordTotal += letterScore * 3
-					explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("%s (%dx3)", string(c.Char), letterScore))
-				case DoubleWordScoreType:
-					wordTotal += letterScore
-					wordBonuses = append(wordBonuses, c.Bonus)
-					explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("%s (%d)", string(c.Char), letterScore))
-				case TripleWordScoreType:
-					wordTotal += letterScore
-					wordBonuses = append(wordBonuses, c.Bonus)
-					explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("%s (%d)", string(c.Char), letterScore))
-				}
+			letterScore := c.LetterScore()
+			letter := c.String()
+			if c.IsBlank {
+				letter = strings.ToLower(letter)
+			}
+			switch c.Bonus {
+			case DoubleLetterScoreType:
+				wordTotal += letterScore * 2
+				explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("%s (%dx2)", letter, letterScore))
+			case TripleLetterScoreType:
+				wordTotal += letterScore * 3
+				explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("%s (%dx3)", letter, letterScore))
+			case DoubleWordScoreType, TripleWordScoreType:
+				wordTotal += letterScore
+				wordBonuses = append(wordBonuses, c.Bonus)
+				explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("%s (%d)", letter, letterScore))
+			default:
+				wordTotal += letterScore
+				explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("%s (%d)", letter, letterScore))
 			}
 		}
 		for _, b := range wordBonuses {
 			switch b {
 			case DoubleWordScoreType:
 				wordTotal = wordTotal * 2
-				explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("[x2]"))
+				explanation[wordIdx] = append(explanation[wordIdx], "[x2]")
 			case TripleWordScoreType:
 				wordTotal = wordTotal * 3
-				explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("[x3]"))
+				explanation[wordIdx] = append(explanation[wordIdx], "[x3]")
 			}
 		}
+		explanation[wordIdx] = append(explanation[wordIdx], fmt.Sprintf("= %d", wordTotal))
 		total = total + wordTotal
 	}
 	if len(r.LettersSpent) == NumPlayerLetters {
-		total += 50
+		total += BingoBonus
+		explanation = append(explanation, []string{fmt.Sprintf("BINGO: +%d", BingoBonus)})
 	}
 	return total, explanation
 }
@@ -2,6 +2,7 @@ package scrabble
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -220,3 +221,206 @@ func TestBoard_nonEmptyNeighbouringCells(t *testing.T) {
 		})
 	}
 }
+
+func TestPlacementResult_Score(t *testing.T) {
+	type args struct {
+		placement Placement
+		word      string
+		firstWord bool
+	}
+	tests := []struct {
+		name        string
+		b           Board
+		args        args
+		want        int
+		wantExplain []string
+	}{
+		{
+			name:        "first word covers the centre double word",
+			b:           NewBoard(15),
+			args:        args{placement: MustParsePlacement("A112"), word: "CAT", firstWord: true},
+			want:        10,
+			wantExplain: []string{"CAT: C (3) A (1) T (1) [x2] = 10"},
+		},
+		{
+			name:        "bonuses under existing tiles are ignored",
+			b:           NewBoard(15, InitialWord{Placement: MustParsePlacement("A112"), Word: "CAT"}),
+			args:        args{placement: MustParsePlacement("A112"), word: "CATS"},
+			want:        6,
+			wantExplain: []string{"CATS: C (3) A (1) T (1) S (1) = 6"},
+		},
+		{
+			name: "cross words score letter bonuses under new tiles",
+			b:    NewBoard(15, InitialWord{Placement: MustParsePlacement("A112"), Word: "CAT"}),
+			args: args{placement: MustParsePlacement("A128"), word: "TA"},
+			want: 8,
+			wantExplain: []string{
+				"TA: T (1) A (1x2) = 3",
+				"AT: A (1) T (1) = 2",
+				"TA: T (1) A (1x2) = 3",
+			},
+		},
+		{
+			name: "cross words score word bonuses under new tiles",
+			b:    NewBoard(15, InitialWord{Placement: MustParsePlacement("A63"), Word: "AT"}),
+			args: args{placement: MustParsePlacement("D65"), word: "SO"},
+			want: 10,
+			wantExplain: []string{
+				"SO: S (1) O (1) [x2] = 4",
+				"ATS: A (1) T (1) S (1) [x2] = 6",
+			},
+		},
+		{
+			name: "triple letter counts in both directions",
+			b:    NewBoard(15, InitialWord{Placement: MustParsePlacement("A96"), Word: "IT"}),
+			args: args{placement: MustParsePlacement("A81"), word: "QA"},
+			want: 64,
+			wantExplain: []string{
+				"QA: Q (10x3) A (1) = 31",
+				"QI: Q (10x3) I (1) = 31",
+				"AT: A (1) T (1) = 2",
+			},
+		},
+		{
+			name: "blanks score zero even on a letter bonus",
+			b:    NewBoard(15, InitialWord{Placement: MustParsePlacement("A112"), Word: "CAT"}),
+			args: args{placement: MustParsePlacement("A128"), word: "Ta"},
+			want: 4,
+			wantExplain: []string{
+				"TA: T (1) a (0x2) = 1",
+				"AT: A (1) T (1) = 2",
+				"TA: T (1) a (0x2) = 1",
+			},
+		},
+		{
+			name: "existing blanks score zero",
+			b:    NewBoard(15, InitialWord{Placement: MustParsePlacement("A112"), Word: "cAT"}),
+			args: args{placement: MustParsePlacement("A111"), word: "SCAT"},
+			want: 3,
+			wantExplain: []string{
+				"SCAT: S (1) c (0) A (1) T (1) = 3",
+			},
+		},
+		{
+			name: "word bonuses are multiplied together",
+			b:    NewBoard(15),
+			args: args{placement: MustParsePlacement("A106"), word: "RETAINER", firstWord: true},
+			want: 54,
+			wantExplain: []string{
+				"RETAINER: R (1) E (1) T (1) A (1x2) I (1) N (1) E (1) R (1) [x3] [x2] = 54",
+			},
+		},
+		{
+			name: "using all seven letters scores a bingo",
+			b:    NewBoard(15),
+			args: args{placement: MustParsePlacement("A107"), word: "RETAINS", firstWord: true},
+			want: 66,
+			wantExplain: []string{
+				"RETAINS: R (1) E (1) T (1x2) A (1) I (1) N (1) S (1) [x2] = 16",
+				"BINGO: +50",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.b.isValidWordPlacement(tt.args.placement, tt.args.word, tt.args.firstWord)
+			if err != nil {
+				t.Fatalf("isValidWordPlacement() error = %v", err)
+			}
+			if got := result.Score(); got != tt.want {
+				t.Errorf("Score() = %v, want %v", got, tt.want)
+			}
+			if got := result.ExplainScore(); !reflect.DeepEqual(got, tt.wantExplain) {
+				t.Errorf("ExplainScore() = %v, want %v", got, tt.wantExplain)
+			}
+		})
+	}
+}
+
+func TestParsePlacement(t *testing.T) {
+	tests := []struct {
+		placement string
+		want      Placement
+		wantErr   bool
+	}{
+		// cell indexes keep their meaning even where they look like a coordinate
+		{placement: "A8", want: Placement{CellId: 8, Direction: Across}},
+		{placement: "D4", want: Placement{CellId: 4, Direction: Down}},
+		{placement: "D15", want: Placement{CellId: 15, Direction: Down}},
+		{placement: "D57", want: Placement{CellId: 57, Direction: Down}},
+		{placement: "A113", want: Placement{CellId: 113, Direction: Across}},
+		{placement: "8A", wantErr: true},
+		{placement: "H8", wantErr: true},
+		{placement: "8H", wantErr: true},
+		{placement: "", wantErr: true},
+		{placement: "AX", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.placement, func(t *testing.T) {
+			got, err := ParsePlacement(tt.placement)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePlacement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParsePlacement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCoordinate(t *testing.T) {
+	tests := []struct {
+		coordinate string
+		want       Placement
+		wantErr    bool
+	}{
+		{coordinate: "A8", want: Placement{CellId: 106, Direction: Down}},
+		{coordinate: "D4", want: Placement{CellId: 49, Direction: Down}},
+		{coordinate: "8A", want: Placement{CellId: 106, Direction: Across}},
+		{coordinate: "H8", want: Placement{CellId: 113, Direction: Down}},
+		{coordinate: "8H", want: Placement{CellId: 113, Direction: Across}},
+		{coordinate: "a15", want: Placement{CellId: 211, Direction: Down}},
+		{coordinate: "D57", wantErr: true},
+		{coordinate: "A113", wantErr: true},
+		{coordinate: "", wantErr: true},
+		{coordinate: "Z1", wantErr: true},
+		{coordinate: "16A", wantErr: true},
+		{coordinate: "8", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.coordinate, func(t *testing.T) {
+			got, err := ParseCoordinate(tt.coordinate)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCoordinate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseCoordinate() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && got.Coordinate() != strings.ToUpper(tt.coordinate) {
+				t.Errorf("Coordinate() = %s, want %s", got.Coordinate(), strings.ToUpper(tt.coordinate))
+			}
+		})
+	}
+}
+
+func TestPlacement_UnmarshalText(t *testing.T) {
+	for cellId := int64(1); cellId <= StandardBoardSize*StandardBoardSize; cellId++ {
+		for _, direction := range []Orientation{Across, Down} {
+			want := Placement{CellId: cellId, Direction: direction}
+			text, err := want.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText() error = %v", err)
+			}
+			got := Placement{}
+			if err := got.UnmarshalText(text); err != nil {
+				t.Fatalf("UnmarshalText(%s) error = %v", text, err)
+			}
+			if got != want {
+				t.Errorf("UnmarshalText(%s) = %v, want %v", text, got, want)
+			}
+		}
+	}
+	if err := (&Placement{}).UnmarshalText([]byte("8H")); err == nil {
+		t.Errorf("UnmarshalText() expected error for a coordinate")
+	}
+}
@@ -0,0 +1,147 @@
+package scrabble
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// BotStrategy decides which play a computer player makes from all the legal plays available to it.
+type BotStrategy interface {
+	Name() string
+	// ChoosePlay returns the play to make or nil if the bot does not want to play any of them.
+	ChoosePlay(rack []rune, plays []*Play) *Play
+}
+
+var (
+	// RandomStrategy plays any legal move. It is the easiest opponent.
+	RandomStrategy BotStrategy = randomStrategy{}
+	// HighestScoreStrategy always makes the highest scoring play.
+	HighestScoreStrategy BotStrategy = highestScoreStrategy{}
+	// EquityStrategy makes the play with the best score plus value of the tiles left on the rack.
+	EquityStrategy BotStrategy = equityStrategy{}
+)
+
+var botStrategies = map[string]BotStrategy{
+	RandomStrategy.Name():       RandomStrategy,
+	HighestScoreStrategy.Name(): HighestScoreStrategy,
+	EquityStrategy.Name():       EquityStrategy,
+}
+
+// BotStrategyByName returns one of the built-in strategies.
+func BotStrategyByName(name string) (BotStrategy, error) {
+	strategy, ok := botStrategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown bot strategy: %s", name)
+	}
+	return strategy, nil
+}
+
+// NewRandomStrategy returns a RandomStrategy that picks plays using the given source so its choices are repeatable.
+func NewRandomStrategy(src rand.Source) BotStrategy {
+	return randomStrategy{rng: rand.New(src)}
+}
+
+type randomStrategy struct {
+	rng *rand.Rand
+}
+
+func (randomStrategy) Name() string {
+	return "random"
+}
+
+func (s randomStrategy) ChoosePlay(rack []rune, plays []*Play) *Play {
+	if len(plays) == 0 {
+		return nil
+	}
+	if s.rng != nil {
+		return plays[s.rng.IntN(len(plays))]
+	}
+	return plays[rand.IntN(len(plays))]
+}
+
+type highestScoreStrategy struct{}
+
+func (highestScoreStrategy) Name() string {
+	return "highest_score"
+}
+
+func (highestScoreStrategy) ChoosePlay(rack []rune, plays []*Play) *Play {
+	var best *Play
+	for _, v := range plays {
+		if best == nil || v.Score > best.Score {
+			best = v
+		}
+	}
+	return best
+}
+
+type equityStrategy struct{}
+
+func (equityStrategy) Name() string {
+	return "equity"
+}
+
+func (equityStrategy) ChoosePlay(rack []rune, plays []*Play) *Play {
+	var best *Play
+	var bestEquity float64
+	for _, v := range plays {
+		leave, err := rackLeave(rack, v.Result.LettersSpent)
+		if err != nil {
+			continue
+		}
+		equity := float64(v.Score) + LeaveValue(leave)
+		if best == nil || equity > bestEquity {
+			best = v
+			bestEquity = equity
+		}
+	}
+	return best
+}
+
+// leaveTileValues is a rough value of keeping each tile on the rack for the next turn.
+var leaveTileValues = map[rune]float64{
+	'_': 25, 'S': 8, 'Z': 3, 'X': 3, 'R': 1.5, 'H': 1, 'E': 1, 'C': 0.5, 'M': 0.5, 'D': 0.5,
+	'N': 0.5, 'T': 0.5, 'L': 0, 'A': 0.5, 'P': -0.5, 'K': -0.5, 'Y': -0.5, 'I': -1, 'J': -1.5,
+	'O': -1.5, 'G': -2, 'B': -2, 'F': -2, 'W': -3, 'U': -3, 'V': -5, 'Q': -7,
+}
+
+// LeaveValue estimates how good the given tiles are to keep, penalising duplicates and an unbalanced mix of
+// vowels and consonants.
+func LeaveValue(leave []rune) float64 {
+	value := 0.0
+	seen := map[rune]int{}
+	vowels := 0
+	for _, v := range leave {
+		value += leaveTileValues[v]
+		if seen[v] > 0 && v != '_' {
+			value -= 3 * float64(seen[v])
+		}
+		seen[v]++
+		switch v {
+		case 'A', 'E', 'I', 'O', 'U':
+			vowels++
+		}
+	}
+	if len(leave) > 0 {
+		consonants := len(leave) - vowels - seen['_']
+		diff := vowels - consonants
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > 1 {
+			value -= 2 * float64(diff-1)
+		}
+	}
+	if seen['Q'] > 0 && seen['U'] > 0 {
+		value += 4
+	}
+	return value
+}
+
+func rackLeave(rack []rune, spent []rune) ([]rune, error) {
+	player := &Player{Letters: rack}
+	if err := player.removeLetters(spent); err != nil {
+		return nil, err
+	}
+	return player.Letters, nil
+}
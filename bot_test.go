@@ -0,0 +1,220 @@
+package scrabble
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func testPlay(word string, score int, spent string) *Play {
+	return &Play{
+		Placement: Placement{CellId: 113, Direction: Across},
+		Word:      word,
+		Score:     score,
+		Result:    &PlacementResult{LettersSpent: []rune(spent)},
+	}
+}
+
+func TestBotStrategyByName(t *testing.T) {
+	for _, name := range []string{"random", "highest_score", "equity"} {
+		strategy, err := BotStrategyByName(name)
+		if err != nil {
+			t.Fatalf("BotStrategyByName(%s) error = %v", name, err)
+		}
+		if strategy.Name() != name {
+			t.Errorf("BotStrategyByName(%s) returned %s", name, strategy.Name())
+		}
+	}
+	if _, err := BotStrategyByName("cheat"); err == nil {
+		t.Errorf("BotStrategyByName() expected error for unknown strategy")
+	}
+}
+
+func TestBotStrategy_ChoosePlay(t *testing.T) {
+	rack := []rune("CATSQVW")
+	// playing CATS leaves QVW which is worth much less than keeping the S
+	cats := testPlay("CATS", 12, "CATS")
+	cat := testPlay("CAT", 10, "CAT")
+	at := testPlay("AT", 2, "AT")
+
+	tests := []struct {
+		name     string
+		strategy BotStrategy
+		plays    []*Play
+		want     *Play
+	}{
+		{name: "highest score", strategy: HighestScoreStrategy, plays: []*Play{at, cat, cats}, want: cats},
+		{name: "highest score first of equal plays", strategy: HighestScoreStrategy, plays: []*Play{cat, testPlay("TAC", 10, "TAC")}, want: cat},
+		{name: "highest score no plays", strategy: HighestScoreStrategy, plays: nil, want: nil},
+		{name: "equity keeps good leave", strategy: EquityStrategy, plays: []*Play{at, cats, cat}, want: cat},
+		{name: "equity ignores plays not on the rack", strategy: EquityStrategy, plays: []*Play{cat, testPlay("ZAX", 50, "ZAX")}, want: cat},
+		{name: "equity no plays", strategy: EquityStrategy, plays: nil, want: nil},
+		{name: "random no plays", strategy: NewRandomStrategy(rand.NewPCG(1, 1)), plays: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.strategy.ChoosePlay(rack, tt.plays); got != tt.want {
+				t.Errorf("ChoosePlay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRandomStrategy(t *testing.T) {
+	plays := []*Play{testPlay("AT", 2, "AT"), testPlay("CAT", 10, "CAT"), testPlay("CATS", 12, "CATS"), testPlay("TA", 2, "TA")}
+	choose := func() []*Play {
+		strategy := NewRandomStrategy(rand.NewPCG(1, 2))
+		chosen := []*Play{}
+		for range 20 {
+			chosen = append(chosen, strategy.ChoosePlay([]rune("CATS"), plays))
+		}
+		return chosen
+	}
+	first, second := choose(), choose()
+	distinct := map[*Play]bool{}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("choice %d differs between strategies with the same seed", i)
+		}
+		distinct[first[i]] = true
+	}
+	if len(distinct) < 2 {
+		t.Errorf("random strategy chose the same play every time")
+	}
+	if NewRandomStrategy(rand.NewPCG(1, 2)).Name() != RandomStrategy.Name() {
+		t.Errorf("seeded random strategy has a different name")
+	}
+}
+
+func TestLeaveValue(t *testing.T) {
+	tests := []struct {
+		leave string
+		want  float64
+	}{
+		{leave: "", want: 0},
+		{leave: "S", want: 8},
+		{leave: "_", want: 25},
+		{leave: "ERS", want: 10.5},
+		{leave: "EE", want: -3},  // duplicate and all vowels
+		{leave: "SSS", want: 11}, // two duplicates and no vowels
+		{leave: "QU", want: -6},  // Q is less bad with a U
+		{leave: "AEIOU", want: -12},
+		{leave: "__", want: 50}, // duplicate blanks are not penalised
+	}
+	for _, tt := range tests {
+		t.Run(tt.leave, func(t *testing.T) {
+			if got := LeaveValue([]rune(tt.leave)); got != tt.want {
+				t.Errorf("LeaveValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newTestBotGame(t *testing.T, strategy BotStrategy, words ...string) *Classic {
+	t.Helper()
+	gaddag, err := NewGADDAG(words)
+	if err != nil {
+		t.Fatalf("NewGADDAG() error = %v", err)
+	}
+	game := NewClassicGame(WithSeed(1), WithLexicon(gaddag))
+	if err := game.AddPlayer("alice"); err != nil {
+		t.Fatalf("AddPlayer() error = %v", err)
+	}
+	if err := game.AddBot("bot", strategy); err != nil {
+		t.Fatalf("AddBot() error = %v", err)
+	}
+	return game
+}
+
+func TestClassic_AddBot_plays(t *testing.T) {
+	tests := []struct {
+		strategy BotStrategy
+		want     string
+	}{
+		{strategy: HighestScoreStrategy, want: "CATS"},
+		// CATS scores two more but keeping the S is worth more than that
+		{strategy: EquityStrategy, want: "CAT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy.Name(), func(t *testing.T) {
+			game := newTestBotGame(t, tt.strategy, "CAT", "CATS", "AT")
+			game.Players[1].Letters = []rune("CATSEEE")
+			if err := game.Pass(); err != nil {
+				t.Fatalf("Pass() error = %v", err)
+			}
+			last := game.History[len(game.History)-1]
+			if last.Player != "bot" || last.Type != TurnPlay || last.Word != tt.want {
+				t.Errorf("bot move = %s %s %s, want a play of %s", last.Player, last.Type, last.Word, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassic_AddBot_noPlays(t *testing.T) {
+	// the only word is too long to be played from a rack
+	game := newTestBotGame(t, HighestScoreStrategy, "ZZZZZZZZ")
+	rack := string(game.Players[1].Letters)
+
+	if err := game.Pass(); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+	last := game.History[len(game.History)-1]
+	if last.Player != "bot" || last.Type != TurnExchange {
+		t.Fatalf("bot move = %s %s, want an exchange", last.Player, last.Type)
+	}
+	if string(last.Exchanged) != rack {
+		t.Errorf("bot exchanged %s, want its whole rack %s", string(last.Exchanged), rack)
+	}
+	if got := game.SpareLetters.Len(); got != 100-2*NumPlayerLetters {
+		t.Errorf("bag has %d tiles after exchange, want %d", got, 100-2*NumPlayerLetters)
+	}
+
+	// with too few tiles to exchange the bot can only pass
+	game.SpareLetters.Fill([]rune("ABC"))
+	if err := game.Pass(); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+	last = game.History[len(game.History)-1]
+	if last.Player != "bot" || last.Type != TurnPass {
+		t.Errorf("bot move = %s %s, want a pass", last.Player, last.Type)
+	}
+	if player, _ := game.GetCurrentPlayer(); player.Name != "alice" {
+		t.Errorf("current player = %s, want alice", player.Name)
+	}
+}
+
+// passStrategy is a custom strategy that never plays.
+type passStrategy struct{}
+
+func (passStrategy) Name() string { return "pass" }
+
+func (passStrategy) ChoosePlay([]rune, []*Play) *Play { return nil }
+
+func TestClassic_AddBot_keepsStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy BotStrategy
+	}{
+		{name: "seeded", strategy: NewRandomStrategy(rand.NewPCG(1, 2))},
+		{name: "custom", strategy: passStrategy{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestBotGame(t, tt.strategy, "CAT")
+			if game.Players[1].Bot != tt.strategy {
+				t.Fatalf("AddBot() strategy = %v, want the one given", game.Players[1].Bot)
+			}
+			if err := game.Replay(game.Events(), -1); err != nil {
+				t.Fatalf("Replay() error = %v", err)
+			}
+			if game.Players[1].Bot != tt.strategy {
+				t.Errorf("replayed strategy = %v, want the one given", game.Players[1].Bot)
+			}
+			if err := game.Restore(game.Snapshot()); err != nil {
+				t.Fatalf("Restore() error = %v", err)
+			}
+			if game.Players[1].Bot != tt.strategy {
+				t.Errorf("restored strategy = %v, want the one given", game.Players[1].Bot)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package scrabble
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ChallengeRule controls how words are checked against the lexicon in a Classic game.
+type ChallengeRule string
+
+const (
+	// ChallengeVoid rejects invalid words as soon as they are played. This is the default.
+	ChallengeVoid ChallengeRule = "void"
+	// ChallengeSingle accepts any word until it is challenged, there is no penalty for a failed challenge.
+	ChallengeSingle ChallengeRule = "single"
+	// ChallengeDouble accepts any word until it is challenged, a failed challenge loses the challenger their turn.
+	ChallengeDouble ChallengeRule = "double"
+	// ChallengeFivePoint accepts any word until it is challenged, a failed challenge awards the challenged player
+	// 5 points.
+	ChallengeFivePoint ChallengeRule = "5-point"
+	// ChallengeTenPoint is the same as ChallengeFivePoint but awards 10 points.
+	ChallengeTenPoint ChallengeRule = "10-point"
+)
+
+// ParseChallengeRule returns the rule with the given name e.g. "double". An empty name is ChallengeVoid.
+func ParseChallengeRule(name string) (ChallengeRule, error) {
+	if name == "" {
+		return ChallengeVoid, nil
+	}
+	rule := ChallengeRule(name)
+	if err := rule.validate(); err != nil {
+		return "", err
+	}
+	return rule, nil
+}
+
+func (r ChallengeRule) validate() error {
+	switch r {
+	case "", ChallengeVoid, ChallengeSingle, ChallengeDouble, ChallengeFivePoint, ChallengeTenPoint:
+		return nil
+	}
+	return fmt.Errorf("unknown challenge rule: %s", r)
+}
+
+func (r ChallengeRule) penaltyPoints() int {
+	switch r {
+	case ChallengeFivePoint:
+		return 5
+	case ChallengeTenPoint:
+		return 10
+	}
+	return 0
+}
+
+// lastPlay is the state needed to withdraw the most recent play if it is successfully challenged.
+type lastPlay struct {
+	before classicState
+	result *PlacementResult
+}
+
+// Challenge checks the most recent play against the lexicon on behalf of the current player. If any word formed
+// by the play is invalid the play is withdrawn: the tiles are returned to the player's rack, any tiles they drew
+// go back to the bag, and the player loses their score for the turn. Otherwise, the challenge rule's penalty
+// is applied. Returns true if the play was withdrawn.
+func (g *Classic) Challenge() (bool, error) {
+	if g.ChallengeRule == ChallengeVoid || g.ChallengeRule == "" {
+		return false, fmt.Errorf("challenges are not allowed when invalid words are rejected automatically")
+	}
+	if g.lexicon == nil {
+		return false, fmt.Errorf("challenges require a lexicon")
+	}
+	if g.lastPlay == nil {
+		return false, fmt.Errorf("there is no play to challenge")
+	}
+	challenged := g.LastMove()
+	if err := validateWords(g.lexicon, g.lastPlay.result); err != nil {
+		if err := g.record(PlayWithdrawn{Player: challenged.Player}); err != nil {
+			return true, err
+		}
+		return true, g.endTurn()
+	}
+
+	failed := ChallengeFailed{
+		Challenged: challenged.Player,
+		Bonus:      g.ChallengeRule.penaltyPoints(),
+		TurnLost:   g.ChallengeRule == ChallengeDouble && !g.Complete,
+	}
+	if !g.Complete {
+		failed.Challenger = g.getCurrentPlayerName()
+	}
+	if err := g.record(failed); err != nil {
+		return false, err
+	}
+	if failed.TurnLost {
+		return false, g.endTurn()
+	}
+	return false, nil
+}
+
+// applyPlayWithdrawn restores the game to how it was before the last play and passes the turn on.
+func (g *Classic) applyPlayWithdrawn(e PlayWithdrawn) error {
+	phony := g.LastMove()
+	if g.lastPlay == nil || phony == nil || phony.Player != e.Player {
+		return fmt.Errorf("there is no play by %s to withdraw", e.Player)
+	}
+	g.saveUndoState(g.snapshot())
+	g.restore(g.lastPlay.before)
+	g.lastPlay = nil
+
+	player, err := g.GetCurrentPlayer()
+	if err != nil {
+		return err
+	}
+	g.applyTurn(player, &Move{
+		Player:     phony.Player,
+		Type:       TurnWithdrawn,
+		Placement:  phony.Placement,
+		Word:       phony.Word,
+		RackBefore: phony.RackBefore,
+	})
+	return nil
+}
+
+// applyChallengeFailed penalises the challenger for challenging a valid play.
+func (g *Classic) applyChallengeFailed(e ChallengeFailed) error {
+	challenged, err := g.getPlayerByName(e.Challenged)
+	if err != nil {
+		return err
+	}
+	var challenger *Player
+	if e.TurnLost {
+		if challenger, err = g.GetCurrentPlayer(); err != nil {
+			return err
+		}
+		if challenger.Name != e.Challenger {
+			return fmt.Errorf("it is not %s's turn", e.Challenger)
+		}
+	}
+	g.saveUndoState(g.snapshot())
+	g.lastPlay = nil
+
+	if e.Bonus != 0 {
+		challenged.Score += e.Bonus
+		g.History = append(g.History, &Move{
+			Player:     challenged.Name,
+			Type:       TurnChallengeBonus,
+			Score:      e.Bonus,
+			RackBefore: slices.Clone(challenged.Letters),
+			RackAfter:  slices.Clone(challenged.Letters),
+		})
+	}
+	if challenger != nil {
+		g.applyTurn(challenger, &Move{Player: challenger.Name, Type: TurnChallengeLost, RackBefore: slices.Clone(challenger.Letters)})
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package scrabble
+
+import "testing"
+
+func newTestChallengeGame(t *testing.T, rule ChallengeRule) *Classic {
+	t.Helper()
+	game := NewClassicGame(WithSeed(1), WithLexicon(NewWordList("CAT")), WithChallengeRule(rule))
+	for _, name := range []string{"alice", "bob"} {
+		if err := game.AddPlayer(name); err != nil {
+			t.Fatalf("AddPlayer() error = %v", err)
+		}
+	}
+	game.Players[0].Letters = []rune("CATXYZQ")
+	return game
+}
+
+func TestClassic_PlaceWord_challengeRule(t *testing.T) {
+	tests := []struct {
+		rule    ChallengeRule
+		wantErr bool
+	}{
+		{rule: ChallengeVoid, wantErr: true},
+		{rule: ChallengeSingle},
+		{rule: ChallengeDouble},
+		{rule: ChallengeFivePoint},
+		{rule: ChallengeTenPoint},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.rule), func(t *testing.T) {
+			game := newTestChallengeGame(t, tt.rule)
+			err := game.PlaceWord(Placement{CellId: 112, Direction: Across}, "XAT")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlaceWord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && game.Players[0].Score != 20 {
+				t.Errorf("score = %d, want 20", game.Players[0].Score)
+			}
+		})
+	}
+}
+
+func TestClassic_Challenge(t *testing.T) {
+	tests := []struct {
+		name           string
+		rule           ChallengeRule
+		word           string
+		wantErr        bool
+		wantWithdrawn  bool
+		wantScores     [2]int
+		wantCurrent    string
+		wantLastMove   TurnType
+		wantLastMoveBy string
+	}{
+		{name: "void", rule: ChallengeVoid, word: "CAT", wantErr: true, wantScores: [2]int{10, 0}, wantCurrent: "bob", wantLastMove: TurnPlay, wantLastMoveBy: "alice"},
+		{name: "single invalid", rule: ChallengeSingle, word: "XAT", wantWithdrawn: true, wantCurrent: "bob", wantLastMove: TurnWithdrawn, wantLastMoveBy: "alice"},
+		{name: "single valid", rule: ChallengeSingle, word: "CAT", wantScores: [2]int{10, 0}, wantCurrent: "bob", wantLastMove: TurnPlay, wantLastMoveBy: "alice"},
+		{name: "double invalid", rule: ChallengeDouble, word: "XAT", wantWithdrawn: true, wantCurrent: "bob", wantLastMove: TurnWithdrawn, wantLastMoveBy: "alice"},
+		{name: "double valid", rule: ChallengeDouble, word: "CAT", wantScores: [2]int{10, 0}, wantCurrent: "alice", wantLastMove: TurnChallengeLost, wantLastMoveBy: "bob"},
+		{name: "five point invalid", rule: ChallengeFivePoint, word: "XAT", wantWithdrawn: true, wantCurrent: "bob", wantLastMove: TurnWithdrawn, wantLastMoveBy: "alice"},
+		{name: "five point valid", rule: ChallengeFivePoint, word: "CAT", wantScores: [2]int{15, 0}, wantCurrent: "bob", wantLastMove: TurnChallengeBonus, wantLastMoveBy: "alice"},
+		{name: "ten point valid", rule: ChallengeTenPoint, word: "CAT", wantScores: [2]int{20, 0}, wantCurrent: "bob", wantLastMove: TurnChallengeBonus, wantLastMoveBy: "alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestChallengeGame(t, tt.rule)
+			rack := string(game.Players[0].Letters)
+			bag := game.SpareLetters.Len()
+			if err := game.PlaceWord(Placement{CellId: 112, Direction: Across}, tt.word); err != nil {
+				t.Fatalf("PlaceWord() error = %v", err)
+			}
+
+			withdrawn, err := game.Challenge()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Challenge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if withdrawn != tt.wantWithdrawn {
+				t.Errorf("Challenge() = %v, want %v", withdrawn, tt.wantWithdrawn)
+			}
+			for i, p := range game.Players {
+				if p.Score != tt.wantScores[i] {
+					t.Errorf("%s score = %d, want %d", p.Name, p.Score, tt.wantScores[i])
+				}
+			}
+			if player, _ := game.GetCurrentPlayer(); player.Name != tt.wantCurrent {
+				t.Errorf("current player = %s, want %s", player.Name, tt.wantCurrent)
+			}
+			if move := game.LastMove(); move.Type != tt.wantLastMove || move.Player != tt.wantLastMoveBy {
+				t.Errorf("last move = %s %s, want %s %s", move.Player, move.Type, tt.wantLastMoveBy, tt.wantLastMove)
+			}
+
+			tilesOnBoard := game.Board.GetCell(112, CellFull) != nil
+			if tilesOnBoard == tt.wantWithdrawn {
+				t.Errorf("tiles on board = %v after challenge", tilesOnBoard)
+			}
+			if tt.wantWithdrawn {
+				if string(game.Players[0].Letters) != rack {
+					t.Errorf("rack = %s after withdrawal, want %s", string(game.Players[0].Letters), rack)
+				}
+				if game.SpareLetters.Len() != bag {
+					t.Errorf("bag has %d tiles after withdrawal, want %d", game.SpareLetters.Len(), bag)
+				}
+			}
+			if _, err := game.Challenge(); err == nil {
+				t.Errorf("second Challenge() expected error")
+			}
+		})
+	}
+}
+
+func TestParseChallengeRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    ChallengeRule
+		wantErr bool
+	}{
+		{name: "", want: ChallengeVoid},
+		{name: "void", want: ChallengeVoid},
+		{name: "single", want: ChallengeSingle},
+		{name: "double", want: ChallengeDouble},
+		{name: "5-point", want: ChallengeFivePoint},
+		{name: "10-point", want: ChallengeTenPoint},
+		{name: "Double", wantErr: true},
+		{name: "5", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseChallengeRule(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseChallengeRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseChallengeRule() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassic_PlaceWord_unknownChallengeRule(t *testing.T) {
+	// a phony must not be accepted for free because of a typo in the rule
+	game := newTestChallengeGame(t, "Double")
+	if err := game.PlaceWord(Placement{CellId: 112, Direction: Across}, "XAT"); err == nil {
+		t.Fatalf("PlaceWord() expected error")
+	}
+	if game.Players[0].Score != 0 || len(game.History) != 0 {
+		t.Errorf("play was recorded under an unknown rule")
+	}
+}
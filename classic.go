@@ -2,78 +2,78 @@ package scrabble
 
 import (
 	"fmt"
-	"math/rand/v2"
 	"slices"
-	"strings"
 )
 
 const NumPlayerLetters = 7
 
+type TurnType string
+
+const (
+	TurnPlay     TurnType = "play"
+	TurnExchange TurnType = "exchange"
+	TurnPass     TurnType = "pass"
+	// TurnWithdrawn is a play that was removed from the board after a successful challenge.
+	TurnWithdrawn TurnType = "withdrawn"
+	// TurnChallengeLost is a turn lost by making an unsuccessful challenge.
+	TurnChallengeLost TurnType = "challenge_lost"
+	// TurnChallengeBonus are points awarded to a player after their play was unsuccessfully challenged.
+	TurnChallengeBonus TurnType = "challenge_bonus"
+)
+
+// Move is a record of a single turn.
+type Move struct {
+	Player    string
+	Type      TurnType
+	Placement Placement
+	Word      string
+	Score     int
+	// Explanation is the breakdown of a play's score as given by PlacementResult.ExplainScore.
+	Explanation []string
+	// Exchanged are the letters returned to the bag by an exchange.
+	Exchanged []rune
+	// Drawn are the letters taken from the bag at the end of the turn.
+	Drawn []rune
+	// RackBefore is the player's rack at the start of the turn.
+	RackBefore []rune
+	// RackAfter is the player's rack at the end of the turn, including any letters drawn.
+	RackAfter []rune
+}
+
 type Player struct {
 	Name    string
 	Letters []rune
 	Score   int
+	// Bot is set for computer controlled players.
+	Bot BotStrategy
 }
 
-func (p *Player) getUsedLetters(letters []rune) (map[rune]int, bool) {
-	lettermap := map[rune]int{}
-
-	foundAll := true
-	for _, l := range p.Letters {
-		if _, ok := lettermap[l]; ok {
-			lettermap[l]++
-		} else {
-			lettermap[l] = 1
-		}
-	}
-	for _, want := range letters {
-		found := false
-		if avail, ok := lettermap[want]; ok && avail > 0 {
-			found = true
-			lettermap[want]--
-		}
-		if !found {
-			// if they have a blank they can use that instead for any letter
-			if avail, ok := lettermap['_']; ok && avail > 0 {
-				found = true
-				lettermap['_']--
-			}
-		}
-		if !found {
-			foundAll = false
-		}
-	}
-
-	return lettermap, foundAll
+func (p *Player) IsBot() bool {
+	return p.Bot != nil
 }
 
 func (p *Player) hasLetters(letters []rune) bool {
-	_, foundAll := p.getUsedLetters(letters)
+	_, foundAll := takeLetters(p.Letters, letters)
 	return foundAll
 }
 
 func (p *Player) removeLetters(letters []rune) error {
-	newLetters := make([]rune, 0, 7)
-	usageMap, foundAll := p.getUsedLetters(letters)
+	remaining, foundAll := takeLetters(p.Letters, letters)
 	if !foundAll {
 		return fmt.Errorf("all letters were not found to be removed")
 	}
-	for letter, avail := range usageMap {
-		for range avail {
-			newLetters = append(newLetters, letter)
-		}
-	}
-	p.Letters = newLetters
+	p.Letters = remaining
 	return nil
 }
 
-func NewClassicGame() *Classic {
+func NewClassicGame(opts ...GameOption) *Classic {
+	options := resolveGameOptions(opts...)
 	game := &Classic{
-		Board:         NewBoard(15),
-		CurrentPlayer: 0,
-		SpareLetters:  makeLetterBag(),
-		Players:       make([]*Player, 0),
+		lexicon:      options.lexicon,
+		SpareLetters: NewTileBag(options.randSource),
 	}
+	// starting a game cannot fail
+	_ = game.record(GameStarted{ChallengeRule: options.challengeRule})
 
 	return game
 }
@@ -82,62 +82,214 @@ type Classic struct {
 	Board          Board
 	Players        []*Player
 	CurrentPlayer  int
-	SpareLetters   []rune
+	SpareLetters   *TileBag
 	NumWordsPlaced int
 	Complete       bool
+	EndReason      EndReason
+	History        []*Move
+	// ScorelessTurns is the number of consecutive turns that did not score.
+	ScorelessTurns int
+	ChallengeRule  ChallengeRule
+
+	lexicon     Lexicon
+	playingBots bool
+	lastPlay    *lastPlay
+	undoStates  []classicState
+	redoStates  []classicState
+	events      []Event
+	subscribers subscribers
 }
 
 func (g *Classic) AddPlayer(name string) error {
-	g.Players = append(g.Players, &Player{Name: name, Letters: make([]rune, 0)})
-	if err := g.refillPlayerLetters(len(g.Players) - 1); err != nil {
+	if err := g.record(PlayerJoined{Player: name}); err != nil {
 		return err
 	}
-	return nil
+	return g.refillPlayerLetters(len(g.Players) - 1)
+}
+
+// AddBot adds a computer controlled player. Bots find their plays using the game lexicon so the game must have been
+// created using a GADDAG lexicon. Bots take their turn automatically once the previous player has finished. Any
+// strategy may be used but games rebuilt from serialized events or snapshots can only restore the built-in ones.
+func (g *Classic) AddBot(name string, strategy BotStrategy) error {
+	if _, ok := g.lexicon.(*GADDAG); !ok {
+		return fmt.Errorf("bots require the game to use a GADDAG lexicon")
+	}
+	if strategy == nil {
+		return fmt.Errorf("bot strategy is required")
+	}
+	if err := g.record(PlayerJoined{Player: name, Bot: strategy.Name(), strategy: strategy}); err != nil {
+		return err
+	}
+	return g.refillPlayerLetters(len(g.Players) - 1)
 }
 
 // PlaceWord places a word on the game, the word must be a whole word even if it is just adding letters
-// to an existing word. Any exising letters are not spent by the player.
+// to an existing word. Any exising letters are not spent by the player. Lower case letters are played
+// using a blank tile.
 func (g *Classic) PlaceWord(place Placement, word string) error {
-	word = strings.ToUpper(word)
-
+	if g.Complete {
+		return fmt.Errorf("game is complete")
+	}
 	player, err := g.GetCurrentPlayer()
 	if err != nil {
 		return err
 	}
+	if err := g.ChallengeRule.validate(); err != nil {
+		return err
+	}
 
 	// is the word valid
 	result, err := g.Board.isValidWordPlacement(place, word, g.NumWordsPlaced == 0)
 	if err != nil {
 		return err
 	}
+	if g.ChallengeRule == ChallengeVoid || g.ChallengeRule == "" {
+		if err := validateWords(g.lexicon, result); err != nil {
+			return err
+		}
+	}
 
 	// do they have the letters required to make the word considering overlaps
 	if !player.hasLetters(result.LettersSpent) {
 		return fmt.Errorf("player does not have all letters of word: %s", word)
 	}
 
-	// spend the letters
-	if err := player.removeLetters(result.LettersSpent); err != nil {
+	placed := WordPlaced{
+		Player:      player.Name,
+		Placement:   place,
+		Word:        word,
+		Score:       result.Score(),
+		Explanation: result.ExplainScore(),
+	}
+	// tiles are only drawn once nothing else can fail so a rejected move does not change the draws that follow
+	if _, _, err := g.checkWordPlaced(placed); err != nil {
 		return err
 	}
+	placed.Drawn = string(g.SpareLetters.pick(NumPlayerLetters - len(player.Letters) + len(result.LettersSpent)))
+	if err := g.record(placed); err != nil {
+		return err
+	}
+	return g.endTurn()
+}
 
-	// update the board
-	_, err = g.Board.placeWord(place, word)
+// Exchange returns the given letters to the bag and replaces them with new ones. This is only allowed while
+// there are at least NumPlayerLetters tiles left in the bag.
+func (g *Classic) Exchange(letters []rune) error {
+	if g.Complete {
+		return fmt.Errorf("game is complete")
+	}
+	player, err := g.GetCurrentPlayer()
 	if err != nil {
-		// we're in trouble here because the letters have already been removed from the player
 		return err
 	}
+	if len(letters) == 0 {
+		return fmt.Errorf("no letters to exchange")
+	}
+	if g.SpareLetters.Len() < NumPlayerLetters {
+		return fmt.Errorf("cannot exchange with fewer than %d tiles in the bag", NumPlayerLetters)
+	}
+	if !player.hasLetters(letters) {
+		return fmt.Errorf("player does not have all letters to exchange: %s", string(letters))
+	}
+
+	exchanged := TilesExchanged{Player: player.Name, Tiles: string(letters)}
+	if _, err := g.checkTilesExchanged(exchanged); err != nil {
+		return err
+	}
+	// new letters are drawn before the old ones are returned so the same letters cannot be drawn again
+	exchanged.Drawn = string(g.SpareLetters.pick(len(letters)))
+	if err := g.record(exchanged); err != nil {
+		return err
+	}
+	return g.endTurn()
+}
 
-	if err := g.refillPlayerLetters(g.CurrentPlayer); err != nil {
+// Pass ends the current player's turn without playing.
+func (g *Classic) Pass() error {
+	if g.Complete {
+		return fmt.Errorf("game is complete")
+	}
+	player, err := g.GetCurrentPlayer()
+	if err != nil {
 		return err
 	}
+	if err := g.record(TurnPassed{Player: player.Name}); err != nil {
+		return err
+	}
+	return g.endTurn()
+}
 
-	// scoring
-	player.Score += result.Score()
+// LastMove returns the most recent turn or nil if no turns have been taken.
+func (g *Classic) LastMove() *Move {
+	if len(g.History) == 0 {
+		return nil
+	}
+	return g.History[len(g.History)-1]
+}
 
-	g.NextPlayer()
+// endTurn ends the game if the last turn finished it, otherwise lets any bots take their turn.
+func (g *Classic) endTurn() error {
+	if reason := g.gameOverReason(); reason != EndNotFinished {
+		return g.record(GameEnded{Reason: reason})
+	}
+	if err := g.PlayBotTurns(); err != nil {
+		return fmt.Errorf("bot turn failed: %w", err)
+	}
+	return nil
+}
 
-	g.NumWordsPlaced++
+func (g *Classic) gameOverReason() EndReason {
+	player, err := g.GetCurrentPlayer()
+	if err == nil && len(player.Letters) == 0 && g.SpareLetters.Len() == 0 {
+		return EndPlayerWentOut
+	}
+	if g.ScorelessTurns >= MaxScorelessTurns {
+		return EndScorelessTurns
+	}
+	return EndNotFinished
+}
+
+// PlayBotTurns makes moves for bots until it is a human player's turn. It is called after every turn so only needs
+// to be called directly if a bot is the first player.
+func (g *Classic) PlayBotTurns() error {
+	if g.playingBots {
+		return nil
+	}
+	g.playingBots = true
+	defer func() {
+		g.playingBots = false
+	}()
+
+	for !g.Complete {
+		player, err := g.GetCurrentPlayer()
+		if err != nil {
+			return err
+		}
+		if !player.IsBot() {
+			return nil
+		}
+		gaddag, ok := g.lexicon.(*GADDAG)
+		if !ok {
+			return fmt.Errorf("bots require the game to use a GADDAG lexicon")
+		}
+		play := player.Bot.ChoosePlay(slices.Clone(player.Letters), GeneratePlays(g.Board, player.Letters, gaddag))
+		if play == nil {
+			// swap the whole rack if possible, otherwise there's nothing to do but pass
+			if g.SpareLetters.Len() >= NumPlayerLetters {
+				if err := g.Exchange(slices.Clone(player.Letters)); err != nil {
+					return fmt.Errorf("%s failed to exchange: %w", player.Name, err)
+				}
+				continue
+			}
+			if err := g.Pass(); err != nil {
+				return fmt.Errorf("%s failed to pass: %w", player.Name, err)
+			}
+			continue
+		}
+		if err := g.PlaceWord(play.Placement, play.Word); err != nil {
+			return fmt.Errorf("%s failed to play %s: %w", player.Name, play.String(), err)
+		}
+	}
 	return nil
 }
 
@@ -150,6 +302,15 @@ func (g *Classic) getPlayer(idx int) (*Player, error) {
 	return nil, fmt.Errorf("unknown player index: %d", idx)
 }
 
+func (g *Classic) getPlayerByName(name string) (*Player, error) {
+	for _, v := range g.Players {
+		if v.Name == name {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown player: %s", name)
+}
+
 func (g *Classic) GetCurrentPlayer() (*Player, error) {
 	for k, v := range g.Players {
 		if k == g.CurrentPlayer {
@@ -175,18 +336,10 @@ func (g *Classic) getNextPlayerIdx() int {
 }
 
 func (g *Classic) NextPlayer() {
-	maxAttempts := len(g.Players)
-	for maxAttempts > 0 {
-		next := g.getNextPlayerIdx()
-		if len(g.Players[next].Letters) > 0 {
-			g.CurrentPlayer = next
-			break
-		}
-		maxAttempts--
-	}
-	if maxAttempts == 0 {
-		g.Complete = true
+	if len(g.Players) == 0 {
+		return
 	}
+	g.CurrentPlayer = g.getNextPlayerIdx()
 }
 
 func (g *Classic) refillPlayerLetters(idx int) error {
@@ -194,12 +347,66 @@ func (g *Classic) refillPlayerLetters(idx int) error {
 	if err != nil {
 		return err
 	}
-	for {
-		if len(player.Letters)+1 > NumPlayerLetters || len(g.SpareLetters) == 0 {
-			return nil
+	if len(player.Letters) >= NumPlayerLetters {
+		return nil
+	}
+	return g.record(TilesDrawn{Player: player.Name, Tiles: string(g.SpareLetters.pick(NumPlayerLetters - len(player.Letters)))})
+}
+
+// classicState is a copy of everything that changes during a turn.
+type classicState struct {
+	board          Board
+	players        []Player
+	currentPlayer  int
+	spareLetters   []rune
+	numWordsPlaced int
+	complete       bool
+	endReason      EndReason
+	history        []*Move
+	scorelessTurns int
+	lastPlay       *lastPlay
+}
+
+func (g *Classic) snapshot() classicState {
+	state := classicState{
+		board:          g.Board.clone(),
+		players:        make([]Player, len(g.Players)),
+		currentPlayer:  g.CurrentPlayer,
+		spareLetters:   g.SpareLetters.Tiles(),
+		numWordsPlaced: g.NumWordsPlaced,
+		complete:       g.Complete,
+		endReason:      g.EndReason,
+		history:        slices.Clone(g.History),
+		scorelessTurns: g.ScorelessTurns,
+		lastPlay:       g.lastPlay,
+	}
+	for i, p := range g.Players {
+		state.players[i] = *p
+		state.players[i].Letters = slices.Clone(p.Letters)
+	}
+	return state
+}
+
+// restore resets the game to a snapshot. The existing player pointers are kept so callers holding them see the
+// restored values.
+func (g *Classic) restore(state classicState) {
+	g.Board = state.board.clone()
+	for i := range state.players {
+		p := state.players[i]
+		p.Letters = slices.Clone(p.Letters)
+		if i < len(g.Players) {
+			*g.Players[i] = p
+		} else {
+			g.Players = append(g.Players, &p)
 		}
-		letterIdx := rand.IntN(len(g.SpareLetters) - 1)
-		player.Letters = append(player.Letters, g.SpareLetters[letterIdx])
-		g.SpareLetters = slices.Delete(g.SpareLetters, letterIdx, letterIdx+1)
 	}
+	g.Players = g.Players[:len(state.players)]
+	g.CurrentPlayer = state.currentPlayer
+	g.SpareLetters.Fill(state.spareLetters)
+	g.NumWordsPlaced = state.numWordsPlaced
+	g.Complete = state.complete
+	g.EndReason = state.endReason
+	g.History = slices.Clone(state.history)
+	g.ScorelessTurns = state.scorelessTurns
+	g.lastPlay = state.lastPlay
 }
@@ -0,0 +1,549 @@
+package scrabble
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newTestClassicGame(t *testing.T, seed uint64, players ...string) *Classic {
+	t.Helper()
+	game := NewClassicGame(WithSeed(seed))
+	for _, name := range players {
+		if err := game.AddPlayer(name); err != nil {
+			t.Fatalf("AddPlayer() error = %v", err)
+		}
+	}
+	return game
+}
+
+func TestClassic_seededDrawsAreRepeatable(t *testing.T) {
+	play := func() *Classic {
+		game := newTestClassicGame(t, 42, "alice", "bob")
+		player, _ := game.GetCurrentPlayer()
+		if err := game.Exchange(player.Letters[:3]); err != nil {
+			t.Fatalf("Exchange() error = %v", err)
+		}
+		return game
+	}
+	first, second := play(), play()
+	for i := range first.Players {
+		if got, want := string(second.Players[i].Letters), string(first.Players[i].Letters); got != want {
+			t.Errorf("player %d letters = %s, want %s", i, got, want)
+		}
+	}
+	if !reflect.DeepEqual(first.SpareLetters.Tiles(), second.SpareLetters.Tiles()) {
+		t.Errorf("bags differ after the same moves")
+	}
+	if got := first.SpareLetters.Len(); got != 100-2*NumPlayerLetters {
+		t.Errorf("bag has %d tiles, want %d", got, 100-2*NumPlayerLetters)
+	}
+}
+
+func TestClassic_rejectedMovesDoNotChangeDraws(t *testing.T) {
+	play := func(rejected ...func(game *Classic) error) *Classic {
+		game := newTestClassicGame(t, 42, "alice", "bob")
+		for i, move := range rejected {
+			if err := move(game); err == nil {
+				t.Fatalf("rejected move %d was accepted", i)
+			}
+		}
+		player, _ := game.GetCurrentPlayer()
+		if err := game.Exchange(player.Letters[:3]); err != nil {
+			t.Fatalf("Exchange() error = %v", err)
+		}
+		player, _ = game.GetCurrentPlayer()
+		word := strings.ToUpper(string(player.Letters[:2]))
+		if err := game.PlaceWord(Placement{CellId: 113, Direction: Across}, strings.ReplaceAll(word, "_", "e")); err != nil {
+			t.Fatalf("PlaceWord() error = %v", err)
+		}
+		return game
+	}
+	first := play()
+	second := play(
+		func(game *Classic) error { return game.PlaceWord(Placement{CellId: 1, Direction: Across}, "ZZ") },
+		func(game *Classic) error { return game.PlaceWord(Placement{CellId: 113, Direction: Across}, "ZZZZ") },
+		func(game *Classic) error { return game.Exchange([]rune("ZZZZ")) },
+		func(game *Classic) error { return game.Exchange(nil) },
+	)
+	for i := range first.Players {
+		if got, want := string(second.Players[i].Letters), string(first.Players[i].Letters); got != want {
+			t.Errorf("player %d letters = %s, want %s", i, got, want)
+		}
+	}
+	if !reflect.DeepEqual(first.SpareLetters.Tiles(), second.SpareLetters.Tiles()) {
+		t.Errorf("bags differ after rejected moves")
+	}
+}
+
+func TestClassic_scorelessTurnsResetByScoringPlay(t *testing.T) {
+	game := newTestClassicGame(t, 1, "alice", "bob")
+	for range MaxScorelessTurns - 1 {
+		if err := game.Pass(); err != nil {
+			t.Fatalf("Pass() error = %v", err)
+		}
+	}
+	player, _ := game.GetCurrentPlayer()
+	player.Letters = []rune("CATXYZQ")
+	if err := game.PlaceWord(Placement{CellId: 112, Direction: Across}, "CAT"); err != nil {
+		t.Fatalf("PlaceWord() error = %v", err)
+	}
+	if err := game.Pass(); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+	if game.Complete {
+		t.Errorf("game ended after %d scoreless turns", game.ScorelessTurns)
+	}
+}
+
+func TestClassic_endGame(t *testing.T) {
+	tests := []struct {
+		name            string
+		racks           []string
+		play            func(g *Classic) error
+		wantReason      EndReason
+		wantScores      []int
+		wantAdjustments []int
+		wantWinner      string
+	}{
+		{
+			name:  "player goes out when the bag is empty",
+			racks: []string{"CAT", "QZ"},
+			play: func(g *Classic) error {
+				return g.PlaceWord(Placement{CellId: 112, Direction: Across}, "CAT")
+			},
+			wantReason:      EndPlayerWentOut,
+			wantScores:      []int{10 + 20, -20},
+			wantAdjustments: []int{20, -20},
+			wantWinner:      "alice",
+		},
+		{
+			name:  "player going out gains every opponent's tiles",
+			racks: []string{"CAT", "QZ", "XE"},
+			play: func(g *Classic) error {
+				return g.PlaceWord(Placement{CellId: 112, Direction: Across}, "CAT")
+			},
+			wantReason:      EndPlayerWentOut,
+			wantScores:      []int{10 + 29, -20, -9},
+			wantAdjustments: []int{29, -20, -9},
+			wantWinner:      "alice",
+		},
+		{
+			name:  "six scoreless turns",
+			racks: []string{"CAT", "QZ"},
+			play: func(g *Classic) error {
+				for range MaxScorelessTurns {
+					if err := g.Pass(); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantReason:      EndScorelessTurns,
+			wantScores:      []int{-5, -20},
+			wantAdjustments: []int{-5, -20},
+			wantWinner:      "alice",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestClassicGame(t, 1, []string{"alice", "bob", "carol"}[:len(tt.racks)]...)
+			game.SpareLetters.Fill(nil)
+			for i, rack := range tt.racks {
+				game.Players[i].Letters = []rune(rack)
+			}
+			if err := tt.play(game); err != nil {
+				t.Fatalf("play error = %v", err)
+			}
+			if !game.Complete {
+				t.Fatalf("game is not complete")
+			}
+			result, err := game.Result()
+			if err != nil {
+				t.Fatalf("Result() error = %v", err)
+			}
+			if result.Reason != tt.wantReason {
+				t.Errorf("Reason = %v, want %v", result.Reason, tt.wantReason)
+			}
+			for i, want := range tt.wantScores {
+				if got := game.Players[i].Score; got != want {
+					t.Errorf("%s score = %d, want %d", game.Players[i].Name, got, want)
+				}
+			}
+			for _, s := range result.Standings {
+				for i, p := range game.Players {
+					if p.Name == s.Player && s.Adjustment != tt.wantAdjustments[i] {
+						t.Errorf("%s adjustment = %d, want %d", s.Player, s.Adjustment, tt.wantAdjustments[i])
+					}
+				}
+			}
+			if result.Winner != tt.wantWinner {
+				t.Errorf("Winner = %v, want %v", result.Winner, tt.wantWinner)
+			}
+			if err := game.Pass(); err == nil {
+				t.Errorf("expected error passing in a complete game")
+			}
+		})
+	}
+}
+
+func TestClassic_UndoRedo(t *testing.T) {
+	game := newTestClassicGame(t, 7, "alice", "bob")
+	game.Players[0].Letters = []rune("CATXYZQ")
+	start := game.Snapshot()
+
+	if err := game.PlaceWord(Placement{CellId: 112, Direction: Across}, "CAT"); err != nil {
+		t.Fatalf("PlaceWord() error = %v", err)
+	}
+	played := game.Snapshot()
+	if err := game.Pass(); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+
+	if err := game.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if !reflect.DeepEqual(game.Snapshot(), played) {
+		t.Errorf("first Undo() did not restore the state after the play")
+	}
+	if err := game.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if !reflect.DeepEqual(game.Snapshot(), start) {
+		t.Errorf("second Undo() did not restore the starting state")
+	}
+	if err := game.Undo(); err == nil {
+		t.Errorf("expected error undoing past the start of the game")
+	}
+
+	if err := game.Redo(); err != nil {
+		t.Fatalf("Redo() error = %v", err)
+	}
+	if !reflect.DeepEqual(game.Snapshot(), played) {
+		t.Errorf("Redo() did not restore the state after the play")
+	}
+	if move := game.LastMove(); string(move.RackBefore) != "CATXYZQ" || len(move.Drawn) != 3 || len(move.Explanation) != 1 {
+		t.Errorf("unexpected move record: %+v", move)
+	}
+}
+
+func TestClassic_AddPlayer_duplicateName(t *testing.T) {
+	game := newTestBotGame(t, HighestScoreStrategy, "CAT")
+	if err := game.AddPlayer("bot"); err == nil {
+		t.Errorf("AddPlayer() expected an error for a duplicate name")
+	}
+	if err := game.AddBot("alice", HighestScoreStrategy); err == nil {
+		t.Errorf("AddBot() expected an error for a duplicate name")
+	}
+	if len(game.Players) != 2 || game.SpareLetters.Len() != 100-2*NumPlayerLetters {
+		t.Errorf("rejected players changed the game: %d players and %d tiles in the bag", len(game.Players), game.SpareLetters.Len())
+	}
+
+	events := append(game.Events(), PlayerJoined{Player: "alice"})
+	if err := NewClassicGame().Replay(events, -1); err == nil {
+		t.Errorf("Replay() expected an error for a duplicate name")
+	}
+}
+
+func TestClassic_Replay(t *testing.T) {
+	game := newTestClassicGame(t, 3, "alice", "bob")
+	snapshots := map[int]*ClassicSnapshot{len(game.Events()): game.Snapshot()}
+	for i := 0; !game.Complete; i++ {
+		player, _ := game.GetCurrentPlayer()
+		var err error
+		if i%2 == 0 {
+			err = game.Exchange(player.Letters[:2])
+		} else {
+			err = game.Pass()
+		}
+		if err != nil {
+			t.Fatalf("turn %d error = %v", i, err)
+		}
+		snapshots[len(game.Events())] = game.Snapshot()
+	}
+
+	events := make([]Event, 0, len(game.Events()))
+	for _, e := range game.Events() {
+		data, err := MarshalEvent(e)
+		if err != nil {
+			t.Fatalf("MarshalEvent() error = %v", err)
+		}
+		decoded, err := UnmarshalEvent(data)
+		if err != nil {
+			t.Fatalf("UnmarshalEvent() error = %v", err)
+		}
+		events = append(events, decoded)
+	}
+
+	replayed := NewClassicGame()
+	for upTo, want := range snapshots {
+		if err := replayed.Replay(events, upTo); err != nil {
+			t.Fatalf("Replay(%d) error = %v", upTo, err)
+		}
+		if got := replayed.Snapshot(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Replay(%d) state differs from the original game", upTo)
+		}
+	}
+}
+
+func TestClassic_Subscribe(t *testing.T) {
+	game := newTestClassicGame(t, 5, "alice", "bob")
+	game.Players[0].Letters = []rune("CATXYZQ")
+
+	got := make([]EventType, 0)
+	unsubscribe := game.Subscribe(func(e Event) {
+		got = append(got, e.EventType())
+		if placed, ok := e.(WordPlaced); ok && len(placed.Explanation) != 1 {
+			t.Errorf("WordPlaced explanation = %v", placed.Explanation)
+		}
+		if changed, ok := e.(TurnChanged); ok && (changed.Player != "bob" || changed.Previous != "alice") {
+			t.Errorf("unexpected turn change: %+v", changed)
+		}
+	})
+	if err := game.PlaceWord(Placement{CellId: 112, Direction: Across}, "CAT"); err != nil {
+		t.Fatalf("PlaceWord() error = %v", err)
+	}
+	unsubscribe()
+	if err := game.Pass(); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+
+	want := []EventType{EventWordPlaced, EventTurnChanged}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+}
+
+func TestClassic_PlaceWord_blanks(t *testing.T) {
+	tests := []struct {
+		name      string
+		rack      string
+		word      string
+		wantErr   bool
+		wantScore int
+		wantBlank map[int64]rune
+	}{
+		{
+			name:      "letters",
+			rack:      "QUIETXY",
+			word:      "QUIET",
+			wantScore: (10*2 + 1 + 1 + 1 + 1) * 2,
+		},
+		{
+			name:      "blank on double letter",
+			rack:      "_UIETXY",
+			word:      "qUIET",
+			wantScore: (0 + 1 + 1 + 1 + 1) * 2,
+			wantBlank: map[int64]rune{109: 'Q'},
+		},
+		{
+			name:      "blank on double word",
+			rack:      "QUIE_XY",
+			word:      "QUIEt",
+			wantScore: (10*2 + 1 + 1 + 1 + 0) * 2,
+			wantBlank: map[int64]rune{113: 'T'},
+		},
+		{
+			name:      "two blanks",
+			rack:      "_UIE_XY",
+			word:      "qUIEt",
+			wantScore: (0 + 1 + 1 + 1 + 0) * 2,
+			wantBlank: map[int64]rune{109: 'Q', 113: 'T'},
+		},
+		{
+			name:    "blank not on rack",
+			rack:    "QUIETXY",
+			word:    "qUIET",
+			wantErr: true,
+		},
+		{
+			name:    "blank must be given a letter",
+			rack:    "_UIETXY",
+			word:    "QUIET",
+			wantErr: true,
+		},
+		{
+			name:    "blank letter in word",
+			rack:    "_UIETXY",
+			word:    "_UIET",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestClassicGame(t, 1, "alice", "bob")
+			game.Players[0].Letters = []rune(tt.rack)
+
+			err := game.PlaceWord(Placement{CellId: 109, Direction: Across}, tt.word)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlaceWord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if string(game.Players[0].Letters) != tt.rack {
+					t.Errorf("rack = %s after failed play, want %s", string(game.Players[0].Letters), tt.rack)
+				}
+				if game.Board.GetCell(113, CellFull) != nil {
+					t.Errorf("failed play left tiles on the board")
+				}
+				return
+			}
+			if got := game.Players[0].Score; got != tt.wantScore {
+				t.Errorf("score = %d, want %d", got, tt.wantScore)
+			}
+			if got := string(takeLettersOrFail(t, game.Players[0].Letters, game.LastMove().Drawn)); got != "XY" {
+				t.Errorf("rack kept %s, want XY", got)
+			}
+			for i := range len(tt.word) {
+				cell := game.Board.GetCell(109+int64(i), CellFull)
+				if cell == nil {
+					t.Fatalf("cell %d is empty", 109+i)
+				}
+				want, blank := tt.wantBlank[int64(cell.Index)]
+				if cell.IsBlank != blank {
+					t.Errorf("cell %d IsBlank = %v, want %v", cell.Index, cell.IsBlank, blank)
+				}
+				if blank && cell.Char != want {
+					t.Errorf("cell %d = %c, want %c", cell.Index, cell.Char, want)
+				}
+				if blank && cell.LetterScore() != 0 {
+					t.Errorf("cell %d blank scores %d", cell.Index, cell.LetterScore())
+				}
+			}
+		})
+	}
+}
+
+// takeLettersOrFail removes the letters from the rack, failing the test if any of them are missing.
+func takeLettersOrFail(t *testing.T, rack []rune, letters []rune) []rune {
+	t.Helper()
+	remaining, ok := takeLetters(rack, letters)
+	if !ok {
+		t.Fatalf("rack %s does not contain %s", string(rack), string(letters))
+	}
+	return remaining
+}
+
+// swapRackOrFail puts the rack back in the bag and takes the letters from the bag in its place so that the game
+// still has exactly one set of tiles.
+func swapRackOrFail(t *testing.T, bag *TileBag, rack []rune, letters string) []rune {
+	t.Helper()
+	bag.Fill(takeLettersOrFail(t, append(bag.Tiles(), rack...), []rune(letters)))
+	return []rune(letters)
+}
+
+func sortedLetters(letters ...[]rune) string {
+	all := []rune{}
+	for _, v := range letters {
+		all = append(all, v...)
+	}
+	slices.Sort(all)
+	return string(all)
+}
+
+func TestClassic_Exchange(t *testing.T) {
+	tests := []struct {
+		name     string
+		rack     string
+		bag      string
+		exchange string
+		wantErr  bool
+	}{
+		{name: "some tiles", rack: "AABCDEF", bag: "XYZQRST", exchange: "AB"},
+		{name: "whole rack", rack: "AABCDEF", bag: "XYZQRSTUV", exchange: "AABCDEF"},
+		{name: "blank", rack: "_ABCDEF", bag: "XYZQRST", exchange: "_"},
+		{name: "fewer than seven tiles in the bag", rack: "AABCDEF", bag: "XYZQRS", exchange: "A", wantErr: true},
+		{name: "tile not on rack", rack: "AABCDEF", bag: "XYZQRST", exchange: "Z", wantErr: true},
+		{name: "more copies than on rack", rack: "AABCDEF", bag: "XYZQRST", exchange: "BB", wantErr: true},
+		{name: "no tiles", rack: "AABCDEF", bag: "XYZQRST", exchange: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestClassicGame(t, 1, "alice", "bob")
+			game.Players[0].Letters = []rune(tt.rack)
+			game.SpareLetters.Fill([]rune(tt.bag))
+
+			err := game.Exchange([]rune(tt.exchange))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exchange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if string(game.Players[0].Letters) != tt.rack {
+					t.Errorf("rack = %s, want %s", string(game.Players[0].Letters), tt.rack)
+				}
+				if got := sortedLetters(game.SpareLetters.Tiles()); got != sortedLetters([]rune(tt.bag)) {
+					t.Errorf("bag = %s, want %s", got, sortedLetters([]rune(tt.bag)))
+				}
+				if player, _ := game.GetCurrentPlayer(); player.Name != "alice" {
+					t.Errorf("turn passed to %s after failed exchange", player.Name)
+				}
+				return
+			}
+
+			move := game.LastMove()
+			if move.Type != TurnExchange || string(move.Exchanged) != tt.exchange || move.Score != 0 {
+				t.Errorf("move = %s %s scoring %d, want exchange of %s", move.Type, string(move.Exchanged), move.Score, tt.exchange)
+			}
+			if len(move.Drawn) != len(tt.exchange) {
+				t.Fatalf("drew %d tiles, want %d", len(move.Drawn), len(tt.exchange))
+			}
+			// the drawn tiles came from the bag before the exchanged ones were returned
+			if _, ok := takeLetters([]rune(tt.bag), move.Drawn); !ok {
+				t.Errorf("drew %s which was not in the bag %s", string(move.Drawn), tt.bag)
+			}
+			kept := takeLettersOrFail(t, []rune(tt.rack), []rune(tt.exchange))
+			if got, want := sortedLetters(game.Players[0].Letters), sortedLetters(kept, move.Drawn); got != want {
+				t.Errorf("rack = %s, want %s", got, want)
+			}
+			remaining := takeLettersOrFail(t, []rune(tt.bag), move.Drawn)
+			if got, want := sortedLetters(game.SpareLetters.Tiles()), sortedLetters(remaining, []rune(tt.exchange)); got != want {
+				t.Errorf("bag = %s, want %s", got, want)
+			}
+			if game.ScorelessTurns != 1 {
+				t.Errorf("ScorelessTurns = %d, want 1", game.ScorelessTurns)
+			}
+			if player, _ := game.GetCurrentPlayer(); player.Name != "bob" {
+				t.Errorf("current player = %s, want bob", player.Name)
+			}
+		})
+	}
+}
+
+func TestClassic_Pass(t *testing.T) {
+	game := newTestClassicGame(t, 1, "alice", "bob")
+	racks := []string{string(game.Players[0].Letters), string(game.Players[1].Letters)}
+	bag := game.SpareLetters.Len()
+
+	for i := range MaxScorelessTurns {
+		if game.Complete {
+			t.Fatalf("game ended after %d passes", i)
+		}
+		player, _ := game.GetCurrentPlayer()
+		if want := []string{"alice", "bob"}[i%2]; player.Name != want {
+			t.Errorf("pass %d by %s, want %s", i+1, player.Name, want)
+		}
+		if err := game.Pass(); err != nil {
+			t.Fatalf("Pass() error = %v", err)
+		}
+		if game.ScorelessTurns != i+1 {
+			t.Errorf("ScorelessTurns = %d after %d passes", game.ScorelessTurns, i+1)
+		}
+		if move := game.LastMove(); move.Type != TurnPass || move.Player != player.Name || move.Score != 0 {
+			t.Errorf("move = %s %s scoring %d, want a pass by %s", move.Player, move.Type, move.Score, player.Name)
+		}
+	}
+	if !game.Complete {
+		t.Fatalf("game not complete after %d passes", MaxScorelessTurns)
+	}
+	for i, p := range game.Players {
+		if string(p.Letters) != racks[i] {
+			t.Errorf("%s rack = %s, want %s", p.Name, string(p.Letters), racks[i])
+		}
+	}
+	if game.SpareLetters.Len() != bag {
+		t.Errorf("bag has %d tiles, want %d", game.SpareLetters.Len(), bag)
+	}
+	if err := game.Pass(); err == nil {
+		t.Errorf("Pass() expected error once the game is complete")
+	}
+}
@@ -0,0 +1,70 @@
+// Command scrabble-server hosts Classic and Scrabulous games over a JSON API, see the server package.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/warmans/go-scrabble"
+	"github.com/warmans/go-scrabble/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	words := flag.String("words", "", "word list file used to validate words, one word per line")
+	gaddag := flag.String("gaddag", "", "compiled GADDAG file used to validate words, takes priority over -words")
+	dataDir := flag.String("data", "", "directory to store games in, games are only kept in memory if empty")
+	flag.Parse()
+
+	opts := make([]server.Option, 0)
+	switch {
+	case *gaddag != "":
+		lexicon, err := scrabble.LoadGADDAGFile(*gaddag)
+		if err != nil {
+			log.Fatalf("failed to load gaddag: %s", err)
+		}
+		opts = append(opts, server.WithLexicon(lexicon))
+	case *words != "":
+		lexicon, err := scrabble.LoadGADDAGWordListFile(*words)
+		if err != nil {
+			log.Fatalf("failed to load word list: %s", err)
+		}
+		opts = append(opts, server.WithLexicon(lexicon))
+	default:
+		log.Print("no word list given, any word will be accepted and bots cannot play")
+	}
+
+	var store server.Store = server.NewMemoryStore()
+	if *dataDir != "" {
+		fileStore, err := server.NewFileStore(*dataDir)
+		if err != nil {
+			log.Fatalf("failed to open data directory: %s", err)
+		}
+		store = fileStore
+	}
+
+	srv := server.New(store, opts...)
+	defer srv.Close()
+
+	httpServer := &http.Server{Addr: *addr, Handler: srv}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("listening on %s", *addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/warmans/go-scrabble"
+)
+
+const (
+	ansiReset     = "\033[0m"
+	ansiBold      = "\033[1m"
+	ansiDim       = "\033[2m"
+	ansiTile      = "\033[30;103m"
+	ansiNewTile   = "\033[30;102m"
+	ansiTW        = "\033[97;41m"
+	ansiDW        = "\033[30;105m"
+	ansiTL        = "\033[97;44m"
+	ansiDL        = "\033[30;106m"
+	ansiEmptyCell = "\033[37;100m"
+)
+
+// centreCell is the star square on a standard board.
+const centreCell = 113
+
+// clearLines is how many blank lines are printed to scroll the screen clear when colour is disabled.
+const clearLines = 50
+
+// boardPrinter draws the board using ANSI colours or, if colour is disabled, plain symbols for premium squares.
+type boardPrinter struct {
+	colour bool
+	// previous is the board as it was last printed so new tiles can be highlighted.
+	previous map[int]rune
+}
+
+func (p *boardPrinter) print(w io.Writer, board scrabble.Board) {
+	columns := make([]string, 0, len(board))
+	for i := range board {
+		columns = append(columns, fmt.Sprintf(" %c ", 'A'+i))
+	}
+	fmt.Fprintf(w, "    %s\n", strings.Join(columns, ""))
+
+	current := make(map[int]rune)
+	for y, row := range board {
+		fmt.Fprintf(w, "%3d ", y+1)
+		for _, cell := range row {
+			if !cell.Empty() {
+				current[cell.Index] = cell.Char
+			}
+			_, seen := p.previous[cell.Index]
+			fmt.Fprint(w, p.cell(cell, p.previous != nil && !seen))
+		}
+		fmt.Fprintf(w, " %d\n", y+1)
+	}
+	fmt.Fprintf(w, "    %s\n", strings.Join(columns, ""))
+	p.previous = current
+}
+
+func (p *boardPrinter) cell(cell scrabble.Cell, isNew bool) string {
+	if !cell.Empty() {
+		char := cell.Char
+		if cell.IsBlank {
+			// blanks are shown in lower case as they are typed
+			char = unicode.ToLower(char)
+		}
+		text := fmt.Sprintf(" %c ", char)
+		if !p.colour {
+			return text
+		}
+		if isNew {
+			return ansiBold + ansiNewTile + text + ansiReset
+		}
+		return ansiBold + ansiTile + text + ansiReset
+	}
+
+	symbol, colour := " . ", ansiEmptyCell
+	switch cell.Bonus {
+	case scrabble.TripleWordScoreType:
+		symbol, colour = " = ", ansiTW
+	case scrabble.DoubleWordScoreType:
+		symbol, colour = " - ", ansiDW
+	case scrabble.TripleLetterScoreType:
+		symbol, colour = ` " `, ansiTL
+	case scrabble.DoubleLetterScoreType:
+		symbol, colour = " ' ", ansiDL
+	}
+	if cell.Index == centreCell {
+		symbol = " * "
+	}
+	if !p.colour {
+		return symbol
+	}
+	return colour + symbol + ansiReset
+}
+
+func (p *boardPrinter) legend(w io.Writer) {
+	if !p.colour {
+		fmt.Fprintln(w, `    = triple word  - double word  " triple letter  ' double letter`)
+		return
+	}
+	fmt.Fprintf(
+		w,
+		"    %s = %s triple word  %s - %s double word  %s \" %s triple letter  %s ' %s double letter  %s A %s new tile\n",
+		ansiTW, ansiReset, ansiDW, ansiReset, ansiTL, ansiReset, ansiDL, ansiReset, ansiNewTile, ansiReset,
+	)
+}
+
+// rack shows each tile with its score e.g. "C3 A1 T1 _0".
+func (p *boardPrinter) rack(letters []rune) string {
+	tiles := make([]string, 0, len(letters))
+	for _, l := range letters {
+		tile := fmt.Sprintf("%c%d", l, scrabble.LetterScores[l])
+		if p.colour {
+			tile = ansiBold + ansiTile + " " + tile + " " + ansiReset
+		}
+		tiles = append(tiles, tile)
+	}
+	return strings.Join(tiles, " ")
+}
+
+// clear hides the previous player's rack from the screen. Without colour the terminal may not support escape
+// codes so the rack is scrolled out of sight instead.
+func (p *boardPrinter) clear(w io.Writer) {
+	if p.colour {
+		fmt.Fprint(w, "\033[H\033[2J")
+		return
+	}
+	fmt.Fprint(w, strings.Repeat("\n", clearLines))
+}
+
+func (p *boardPrinter) dim(text string) string {
+	if !p.colour {
+		return text
+	}
+	return ansiDim + text + ansiReset
+}
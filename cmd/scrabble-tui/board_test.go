@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/warmans/go-scrabble"
+)
+
+func mustParseCoordinate(t *testing.T, coordinate string) scrabble.Placement {
+	t.Helper()
+	place, err := scrabble.ParseCoordinate(coordinate)
+	if err != nil {
+		t.Fatalf("ParseCoordinate() error = %v", err)
+	}
+	return place
+}
+
+func TestBoardPrinter_print(t *testing.T) {
+	// words are placed with coordinates so the printed labels must agree with them
+	board := scrabble.NewBoard(
+		scrabble.StandardBoardSize,
+		scrabble.InitialWord{Placement: mustParseCoordinate(t, "8H"), Word: "CAT"},
+		scrabble.InitialWord{Placement: mustParseCoordinate(t, "A8"), Word: "DOG"},
+		scrabble.InitialWord{Placement: mustParseCoordinate(t, "D4"), Word: "PIG"},
+	)
+	want := strings.Join([]string{
+		`     A  B  C  D  E  F  G  H  I  J  K  L  M  N  O `,
+		`  1  =  .  .  '  .  .  .  =  .  .  .  '  .  .  =  1`,
+		`  2  .  -  .  .  .  "  .  .  .  "  .  .  .  -  .  2`,
+		`  3  .  .  -  .  .  .  '  .  '  .  .  .  -  .  .  3`,
+		`  4  '  .  .  P  .  .  .  '  .  .  .  -  .  .  '  4`,
+		`  5  .  .  .  I  -  .  .  .  .  .  -  .  .  .  .  5`,
+		`  6  .  "  .  G  .  "  .  .  .  "  .  .  .  "  .  6`,
+		`  7  .  .  '  .  .  .  '  .  '  .  .  .  '  .  .  7`,
+		`  8  D  .  .  '  .  .  .  C  A  T  .  '  .  .  =  8`,
+		`  9  O  .  '  .  .  .  '  .  '  .  .  .  '  .  .  9`,
+		` 10  G  "  .  .  .  "  .  .  .  "  .  .  .  "  .  10`,
+		` 11  .  .  .  .  -  .  .  .  .  .  -  .  .  .  .  11`,
+		` 12  '  .  .  -  .  .  .  '  .  .  .  -  .  .  '  12`,
+		` 13  .  .  -  .  .  .  '  .  '  .  .  .  -  .  .  13`,
+		` 14  .  -  .  .  .  "  .  .  .  "  .  .  .  -  .  14`,
+		` 15  =  .  .  '  .  .  .  =  .  .  .  '  .  .  =  15`,
+		`     A  B  C  D  E  F  G  H  I  J  K  L  M  N  O `,
+		``,
+	}, "\n")
+
+	buf := &bytes.Buffer{}
+	(&boardPrinter{}).print(buf, board)
+	if got := buf.String(); got != want {
+		t.Errorf("print() = \n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBoardPrinter_print_centre(t *testing.T) {
+	buf := &bytes.Buffer{}
+	(&boardPrinter{}).print(buf, scrabble.NewBoard(scrabble.StandardBoardSize))
+	lines := strings.Split(buf.String(), "\n")
+	if want := `  8  =  .  .  '  .  .  .  *  .  .  .  '  .  .  =  8`; lines[8] != want {
+		t.Errorf("row 8 = %q, want %q", lines[8], want)
+	}
+}
+
+func TestBoardPrinter_print_blank(t *testing.T) {
+	board := scrabble.NewBoard(scrabble.StandardBoardSize, scrabble.InitialWord{Placement: mustParseCoordinate(t, "8H"), Word: "CaT"})
+	buf := &bytes.Buffer{}
+	(&boardPrinter{}).print(buf, board)
+	lines := strings.Split(buf.String(), "\n")
+	if want := `  8  =  .  .  '  .  .  .  C  a  T  .  '  .  .  =  8`; lines[8] != want {
+		t.Errorf("row 8 = %q, want %q", lines[8], want)
+	}
+}
+
+func TestBoardPrinter_print_newTiles(t *testing.T) {
+	p := &boardPrinter{colour: true}
+	board := scrabble.NewBoard(scrabble.StandardBoardSize, scrabble.InitialWord{Placement: mustParseCoordinate(t, "8H"), Word: "CAT"})
+
+	// nothing is new the first time the board is printed
+	buf := &bytes.Buffer{}
+	p.print(buf, board)
+	if strings.Contains(buf.String(), ansiNewTile) {
+		t.Errorf("first print highlighted new tiles")
+	}
+
+	if _, placed := board.SetCell(mustParseCoordinate(t, "8K").CellId, 'S'); !placed {
+		t.Fatalf("failed to place S")
+	}
+	buf.Reset()
+	p.print(buf, board)
+	if got := strings.Count(buf.String(), ansiNewTile); got != 1 {
+		t.Errorf("highlighted %d new tiles, want 1", got)
+	}
+	if !strings.Contains(buf.String(), ansiBold+ansiNewTile+" S "+ansiReset) {
+		t.Errorf("S is not highlighted as a new tile")
+	}
+	if got := strings.Count(buf.String(), ansiTile); got != 3 {
+		t.Errorf("printed %d old tiles, want 3", got)
+	}
+}
+
+func TestBoardPrinter_rack(t *testing.T) {
+	if got := (&boardPrinter{}).rack([]rune("CAT_")); got != "C3 A1 T1 _0" {
+		t.Errorf("rack() = %s, want C3 A1 T1 _0", got)
+	}
+	if got := (&boardPrinter{colour: true}).rack([]rune("Q")); got != ansiBold+ansiTile+" Q10 "+ansiReset {
+		t.Errorf("rack() = %q", got)
+	}
+}
+
+func TestBoardPrinter_clear(t *testing.T) {
+	buf := &bytes.Buffer{}
+	(&boardPrinter{}).clear(buf)
+	if got := strings.Count(buf.String(), "\n"); got != clearLines {
+		t.Errorf("clear() printed %d lines, want %d", got, clearLines)
+	}
+	buf.Reset()
+	(&boardPrinter{colour: true}).clear(buf)
+	if got := buf.String(); got != "\033[H\033[2J" {
+		t.Errorf("clear() = %q", got)
+	}
+}
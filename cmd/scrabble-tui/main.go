@@ -0,0 +1,369 @@
+// Command scrabble-tui plays a Classic game in the terminal. Several people can play at the same terminal taking
+// turns (hot-seat) and bots can be added as opponents.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/warmans/go-scrabble"
+)
+
+const help = `Commands:
+  <coordinate> <WORD> play a word e.g. "8H CAT" (across) or "H8 CAT" (down)
+                      use a lower case letter to play a blank e.g. "8H CaT"
+  exchange <TILES>    swap tiles with the bag e.g. "exchange QZ"
+  pass                end your turn without playing
+  challenge           challenge the last play (only with a challenge rule other than void)
+  hint                show the highest scoring plays (needs a word list)
+  undo, redo          take back or replay your last turn and any bot turns after it
+  help                show this message
+  quit                leave the game`
+
+var errQuit = errors.New("quit")
+
+func main() {
+	players := flag.String("players", "player 1", "comma separated names of the people playing, they take turns at this terminal")
+	bots := flag.String("bots", "", `comma separated bot opponents as name:strategy e.g. "robot:equity", strategies are random, highest_score and equity`)
+	words := flag.String("words", "", "word list file used to validate words, one word per line, required for bots")
+	gaddag := flag.String("gaddag", "", "compiled GADDAG file used to validate words, takes priority over -words")
+	challenge := flag.String("challenge", string(scrabble.ChallengeVoid), "challenge rule: void, single, double, 5-point or 10-point")
+	seed := flag.Uint64("seed", 0, "seed for drawing tiles so a game can be repeated, random if zero")
+	noColour := flag.Bool("no-color", false, "do not use ANSI colours")
+	flag.Parse()
+
+	rule, err := scrabble.ParseChallengeRule(*challenge)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts := []scrabble.GameOption{scrabble.WithChallengeRule(rule)}
+	if *seed != 0 {
+		opts = append(opts, scrabble.WithSeed(*seed))
+	}
+	var lexicon *scrabble.GADDAG
+	switch {
+	case *gaddag != "":
+		var err error
+		if lexicon, err = scrabble.LoadGADDAGFile(*gaddag); err != nil {
+			log.Fatalf("failed to load gaddag: %s", err)
+		}
+	case *words != "":
+		var err error
+		if lexicon, err = scrabble.LoadGADDAGWordListFile(*words); err != nil {
+			log.Fatalf("failed to load word list: %s", err)
+		}
+	}
+	if lexicon != nil {
+		opts = append(opts, scrabble.WithLexicon(lexicon))
+	}
+
+	game := scrabble.NewClassicGame(opts...)
+	for _, name := range splitList(*players) {
+		if err := game.AddPlayer(name); err != nil {
+			log.Fatal(err)
+		}
+	}
+	for _, bot := range splitList(*bots) {
+		name, strategyName, ok := strings.Cut(bot, ":")
+		if !ok {
+			strategyName = "equity"
+		}
+		strategy, err := scrabble.BotStrategyByName(strategyName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := game.AddBot(name, strategy); err != nil {
+			log.Fatalf("failed to add bot %s: %s", name, err)
+		}
+	}
+	if len(game.Players) == 0 {
+		log.Fatal("at least one player is required")
+	}
+
+	t := &tui{
+		game:    game,
+		lexicon: lexicon,
+		in:      bufio.NewScanner(os.Stdin),
+		out:     os.Stdout,
+		printer: &boardPrinter{colour: !*noColour && os.Getenv("NO_COLOR") == ""},
+	}
+	if err := t.run(); err != nil && !errors.Is(err, errQuit) {
+		log.Fatal(err)
+	}
+}
+
+type tui struct {
+	game    *scrabble.Classic
+	lexicon *scrabble.GADDAG
+	in      *bufio.Scanner
+	out     io.Writer
+	printer *boardPrinter
+	// reported is the number of moves in the game history that have been shown.
+	reported int
+	// lastHuman is the last person whose rack was shown, used to hide racks between hot-seat turns.
+	lastHuman string
+}
+
+func (t *tui) run() error {
+	fmt.Fprintln(t.out, help)
+	for {
+		// bots don't take their turn by themselves after an undo or if they play first
+		if err := t.game.PlayBotTurns(); err != nil {
+			return err
+		}
+		if t.game.Complete {
+			break
+		}
+		player, err := t.game.GetCurrentPlayer()
+		if err != nil {
+			return err
+		}
+		if err := t.handover(player); err != nil {
+			return err
+		}
+		t.showGame(player)
+
+		line, err := t.prompt(fmt.Sprintf("%s> ", player.Name))
+		if err != nil {
+			return err
+		}
+		if err := t.command(line); err != nil {
+			if errors.Is(err, errQuit) {
+				return err
+			}
+			fmt.Fprintf(t.out, "%s\n", err)
+		}
+	}
+	t.reportMoves()
+	t.printer.print(t.out, t.game.Board)
+	return t.showResult()
+}
+
+// handover hides the board between turns when more than one person is playing at the terminal.
+func (t *tui) handover(player *scrabble.Player) error {
+	humans := 0
+	for _, p := range t.game.Players {
+		if !p.IsBot() {
+			humans++
+		}
+	}
+	if humans < 2 || t.lastHuman == player.Name {
+		return nil
+	}
+	t.reportMoves()
+	if t.lastHuman != "" {
+		if _, err := t.prompt(fmt.Sprintf("\nPass the keyboard to %s and press enter...", player.Name)); err != nil {
+			return err
+		}
+		t.printer.clear(t.out)
+	}
+	t.lastHuman = player.Name
+	return nil
+}
+
+func (t *tui) showGame(player *scrabble.Player) {
+	t.reportMoves()
+	fmt.Fprintln(t.out)
+	t.printer.print(t.out, t.game.Board)
+	t.printer.legend(t.out)
+	fmt.Fprintln(t.out)
+	for i, p := range t.game.Players {
+		marker := " "
+		if i == t.game.CurrentPlayer {
+			marker = ">"
+		}
+		fmt.Fprintf(t.out, "%s %-20s %4d\n", marker, p.Name, p.Score)
+	}
+	fmt.Fprintf(t.out, "%s\n", t.printer.dim(fmt.Sprintf("%d tiles in the bag", t.game.SpareLetters.Len())))
+	fmt.Fprintf(t.out, "\nRack: %s\n", t.printer.rack(player.Letters))
+}
+
+// reportMoves describes any moves made since they were last reported including bot turns.
+func (t *tui) reportMoves() {
+	if t.reported > len(t.game.History) {
+		// turns were undone
+		t.reported = len(t.game.History)
+	}
+	for _, move := range t.game.History[t.reported:] {
+		fmt.Fprintln(t.out, describeMove(move))
+		for _, explanation := range move.Explanation {
+			fmt.Fprintf(t.out, "    %s\n", t.printer.dim(explanation))
+		}
+	}
+	t.reported = len(t.game.History)
+}
+
+func describeMove(move *scrabble.Move) string {
+	switch move.Type {
+	case scrabble.TurnPlay:
+		return fmt.Sprintf("%s played %s at %s for %d", move.Player, move.Word, move.Placement.Coordinate(), move.Score)
+	case scrabble.TurnExchange:
+		return fmt.Sprintf("%s exchanged %d tiles", move.Player, len(move.Exchanged))
+	case scrabble.TurnPass:
+		return fmt.Sprintf("%s passed", move.Player)
+	case scrabble.TurnWithdrawn:
+		return fmt.Sprintf("%s's play %s was challenged off the board", move.Player, move.Word)
+	case scrabble.TurnChallengeLost:
+		return fmt.Sprintf("%s lost their turn for an unsuccessful challenge", move.Player)
+	case scrabble.TurnChallengeBonus:
+		return fmt.Sprintf("%s scored %d for an unsuccessful challenge", move.Player, move.Score)
+	}
+	return fmt.Sprintf("%s: %s", move.Player, move.Type)
+}
+
+func (t *tui) command(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	switch strings.ToLower(fields[0]) {
+	case "quit", "exit":
+		return errQuit
+	case "help", "?":
+		fmt.Fprintln(t.out, help)
+		return nil
+	case "pass":
+		return t.game.Pass()
+	case "exchange", "swap":
+		if len(fields) != 2 {
+			return fmt.Errorf("usage: exchange <TILES>")
+		}
+		return t.game.Exchange([]rune(strings.ToUpper(fields[1])))
+	case "challenge":
+		withdrawn, err := t.game.Challenge()
+		if err != nil {
+			return err
+		}
+		if !withdrawn {
+			fmt.Fprintln(t.out, "the play is valid")
+		}
+		return nil
+	case "hint":
+		return t.hint()
+	case "undo":
+		return t.undo()
+	case "redo":
+		return t.redo()
+	}
+
+	if len(fields) != 2 {
+		return fmt.Errorf("unknown command, type help to see the commands")
+	}
+	place, err := scrabble.ParseCoordinate(fields[0])
+	if err != nil {
+		return err
+	}
+	return t.game.PlaceWord(place, normaliseWord(fields[1]))
+}
+
+// undo takes back turns until it is a person's turn again, otherwise a bot would just play again.
+func (t *tui) undo() error {
+	if err := t.game.Undo(); err != nil {
+		return err
+	}
+	for t.game.CanUndo() {
+		player, err := t.game.GetCurrentPlayer()
+		if err != nil {
+			return err
+		}
+		if !player.IsBot() {
+			return nil
+		}
+		if err := t.game.Undo(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redo replays turns including the bot turns that followed them.
+func (t *tui) redo() error {
+	if err := t.game.Redo(); err != nil {
+		return err
+	}
+	for t.game.CanRedo() {
+		player, err := t.game.GetCurrentPlayer()
+		if err != nil {
+			return err
+		}
+		if !player.IsBot() {
+			return nil
+		}
+		if err := t.game.Redo(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *tui) hint() error {
+	if t.lexicon == nil {
+		return fmt.Errorf("hints need a word list")
+	}
+	player, err := t.game.GetCurrentPlayer()
+	if err != nil {
+		return err
+	}
+	plays := scrabble.GeneratePlays(t.game.Board, player.Letters, t.lexicon)
+	if len(plays) == 0 {
+		fmt.Fprintln(t.out, "there are no plays, try an exchange")
+		return nil
+	}
+	for _, play := range plays[:min(len(plays), 5)] {
+		fmt.Fprintf(t.out, "  %-4s %-15s %d\n", play.Placement.Coordinate(), play.Word, play.Score)
+	}
+	return nil
+}
+
+func (t *tui) showResult() error {
+	result, err := t.game.Result()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(t.out, "\nGame over")
+	for _, s := range result.Standings {
+		fmt.Fprintf(t.out, "  %-20s %4d (%+d)\n", s.Player, s.Score, s.Adjustment)
+	}
+	if result.Tied {
+		fmt.Fprintln(t.out, "The game is tied")
+	} else {
+		fmt.Fprintf(t.out, "%s wins\n", result.Winner)
+	}
+	return nil
+}
+
+func (t *tui) prompt(text string) (string, error) {
+	fmt.Fprint(t.out, text)
+	if !t.in.Scan() {
+		if err := t.in.Err(); err != nil {
+			return "", err
+		}
+		return "", errQuit
+	}
+	return t.in.Text(), nil
+}
+
+// normaliseWord upper cases a word except for letters that should be played with a blank, which are lower case.
+// A word typed entirely in lower case is taken to have no blanks.
+func normaliseWord(word string) string {
+	if strings.ToLower(word) == word {
+		return strings.ToUpper(word)
+	}
+	return word
+}
+
+func splitList(list string) []string {
+	out := make([]string, 0)
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/warmans/go-scrabble"
+)
+
+func newTestTui(t *testing.T, racks ...string) *tui {
+	t.Helper()
+	game := scrabble.NewClassicGame(scrabble.WithSeed(1))
+	for i, name := range []string{"alice", "bob"} {
+		if err := game.AddPlayer(name); err != nil {
+			t.Fatalf("AddPlayer() error = %v", err)
+		}
+		if i < len(racks) {
+			game.Players[i].Letters = []rune(racks[i])
+		}
+	}
+	return &tui{game: game, out: &bytes.Buffer{}, printer: &boardPrinter{}}
+}
+
+func TestTui_command(t *testing.T) {
+	tests := []struct {
+		name      string
+		racks     []string
+		lines     []string
+		wantErr   bool
+		wantCells map[int64]rune
+		wantBlank []int64
+	}{
+		{name: "across coordinate", racks: []string{"CATXYZQ"}, lines: []string{"8H CAT"}, wantCells: map[int64]rune{113: 'C', 114: 'A', 115: 'T'}},
+		{name: "down coordinate", racks: []string{"CATXYZQ"}, lines: []string{"h8 cat"}, wantCells: map[int64]rune{113: 'C', 128: 'A', 143: 'T'}},
+		{name: "cell index", racks: []string{"CATXYZQ"}, lines: []string{"A113 CAT"}, wantErr: true},
+		{
+			// D6 is column D row 6 not cell 6
+			name:      "down coordinate in column D",
+			racks:     []string{"CATSOFT", "OFXYZQE"},
+			lines:     []string{"8B CATSOFT", "D6 OFT"},
+			wantCells: map[int64]rune{79: 'O', 94: 'F', 109: 'T'},
+		},
+		{
+			// A8 is column A row 8 not cell 8 across
+			name:      "down coordinate in column A",
+			racks:     []string{"CATSOFT", "OXYZQEE"},
+			lines:     []string{"8B CATSOFT", "A8 OX"},
+			wantCells: map[int64]rune{106: 'O', 107: 'C', 121: 'X'},
+		},
+		{name: "blank", racks: []string{"CT_XYZQ"}, lines: []string{"8H CaT"}, wantCells: map[int64]rune{113: 'C', 114: 'A', 115: 'T'}, wantBlank: []int64{114}},
+		{name: "off the board", racks: []string{"CATXYZQ"}, lines: []string{"16A CAT"}, wantErr: true},
+		{name: "invalid coordinate", racks: []string{"CATXYZQ"}, lines: []string{"Z9 CAT"}, wantErr: true},
+		{name: "placement without a word", racks: []string{"CATXYZQ"}, lines: []string{"8H"}, wantErr: true},
+		{name: "exchange without tiles", lines: []string{"exchange"}, wantErr: true},
+		{name: "blank line", lines: []string{"  "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tui := newTestTui(t, tt.racks...)
+			var err error
+			for _, line := range tt.lines {
+				if err = tui.command(line); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("command() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for id, want := range tt.wantCells {
+				if cell := tui.game.Board.GetCell(id, scrabble.CellFull); cell == nil || cell.Char != want {
+					t.Errorf("cell %d = %v, want %c", id, cell, want)
+				}
+			}
+			for _, id := range tt.wantBlank {
+				if cell := tui.game.Board.GetCell(id, scrabble.CellFull); cell == nil || !cell.IsBlank {
+					t.Errorf("cell %d = %v, want a blank", id, cell)
+				}
+			}
+		})
+	}
+}
+
+func TestTui_command_turns(t *testing.T) {
+	tests := []struct {
+		line     string
+		wantType scrabble.TurnType
+	}{
+		{line: "pass", wantType: scrabble.TurnPass},
+		{line: "exchange xyz", wantType: scrabble.TurnExchange},
+		{line: "SWAP XYZ", wantType: scrabble.TurnExchange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			tui := newTestTui(t, "CATXYZQ")
+			if err := tui.command(tt.line); err != nil {
+				t.Fatalf("command() error = %v", err)
+			}
+			if move := tui.game.LastMove(); move == nil || move.Player != "alice" || move.Type != tt.wantType {
+				t.Errorf("last move = %v, want alice %s", move, tt.wantType)
+			}
+		})
+	}
+
+	tui := newTestTui(t)
+	for _, line := range []string{"quit", "EXIT"} {
+		if err := tui.command(line); !errors.Is(err, errQuit) {
+			t.Errorf("command(%s) error = %v, want errQuit", line, err)
+		}
+	}
+}
+
+func TestTui_run_bots(t *testing.T) {
+	tests := []struct {
+		name      string
+		botFirst  bool
+		input     string
+		wantMoves int
+	}{
+		// the bot's reply is taken back with the person's turn
+		{name: "undo", input: "pass\nundo\nquit\n", wantMoves: 0},
+		{name: "redo", input: "pass\nundo\nredo\nquit\n", wantMoves: 2},
+		// nothing is left to undo once the bot's first turn is taken back so it plays again
+		{name: "undo the first turn", botFirst: true, input: "undo\nquit\n", wantMoves: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gaddag, err := scrabble.NewGADDAG([]string{"AT", "TA"})
+			if err != nil {
+				t.Fatalf("NewGADDAG() error = %v", err)
+			}
+			game := scrabble.NewClassicGame(scrabble.WithSeed(1), scrabble.WithLexicon(gaddag))
+			join := []func() error{
+				func() error { return game.AddPlayer("alice") },
+				func() error { return game.AddBot("bot", scrabble.HighestScoreStrategy) },
+			}
+			if tt.botFirst {
+				join[0], join[1] = join[1], join[0]
+			}
+			for _, f := range join {
+				if err := f(); err != nil {
+					t.Fatalf("joining error = %v", err)
+				}
+			}
+
+			tui := &tui{game: game, in: bufio.NewScanner(strings.NewReader(tt.input)), out: &bytes.Buffer{}, printer: &boardPrinter{}}
+			if err := tui.run(); !errors.Is(err, errQuit) {
+				t.Fatalf("run() error = %v, want errQuit", err)
+			}
+			if got := len(game.History); got != tt.wantMoves {
+				t.Errorf("game has %d moves, want %d", got, tt.wantMoves)
+			}
+			if player, _ := game.GetCurrentPlayer(); player.Name != "alice" {
+				t.Errorf("current player = %s, want alice", player.Name)
+			}
+		})
+	}
+}
+
+func TestNormaliseWord(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{word: "cat", want: "CAT"},
+		{word: "CAT", want: "CAT"},
+		{word: "CaT", want: "CaT"},
+	}
+	for _, tt := range tests {
+		if got := normaliseWord(tt.word); got != tt.want {
+			t.Errorf("normaliseWord(%s) = %s, want %s", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	if got := splitList(" alice, bob ,,"); !reflect.DeepEqual(got, []string{"alice", "bob"}) {
+		t.Errorf("splitList() = %v", got)
+	}
+	if got := splitList(""); len(got) != 0 {
+		t.Errorf("splitList() = %v, want nothing", got)
+	}
+}
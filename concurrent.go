@@ -0,0 +1,171 @@
+package scrabble
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ConcurrentScrabulous is a Scrabulous game that is safe to use from many goroutines. Instead of relying on
+// callers to poll TryPlacePendingWord it places the best pending word itself once the steal window closes.
+// The game stops when the context is cancelled.
+type ConcurrentScrabulous struct {
+	mu   sync.Mutex
+	ctx  context.Context
+	game *Scrabulous
+
+	timer    Timer
+	timerGen int
+	deadline time.Time
+
+	handler func(Event)
+	queue   []Event
+	notify  chan struct{}
+}
+
+func NewConcurrentScrabulous(ctx context.Context, stealTime time.Duration, opts ...GameOption) *ConcurrentScrabulous {
+	options := resolveGameOptions(opts...)
+	c := &ConcurrentScrabulous{
+		ctx:     ctx,
+		game:    NewScrabulousGame(stealTime, opts...),
+		handler: options.eventHandler,
+		notify:  make(chan struct{}, 1),
+	}
+	if c.handler != nil {
+		// the game is only used while holding the lock so the queue is too
+		c.game.Subscribe(func(e Event) {
+			c.queue = append(c.queue, e)
+		})
+		go c.dispatch()
+	}
+	context.AfterFunc(ctx, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.stopTimer()
+	})
+	return c
+}
+
+// CreatePendingWord submits a word on behalf of the player. The first word opens the steal window.
+func (c *ConcurrentScrabulous) CreatePendingWord(place Placement, word string, playerName string) (*PlacementResult, error) {
+	var result *PlacementResult
+	err := c.Do(func(game *Scrabulous) error {
+		// the timer may not have run yet when the window closes so a late word must not get into it
+		if err := game.TryPlacePendingWord(); err != nil {
+			return err
+		}
+		var err error
+		result, err = game.CreatePendingWord(place, word, playerName)
+		return err
+	})
+	return result, err
+}
+
+// PlacePendingWord places the best pending word without waiting for the steal window to close.
+func (c *ConcurrentScrabulous) PlacePendingWord() error {
+	return c.Do(func(game *Scrabulous) error {
+		return game.PlacePendingWord()
+	})
+}
+
+func (c *ConcurrentScrabulous) ResetLetters() error {
+	return c.Do(func(game *Scrabulous) error {
+		game.ResetLetters()
+		return nil
+	})
+}
+
+func (c *ConcurrentScrabulous) ResetGame() error {
+	return c.Do(func(game *Scrabulous) error {
+		game.ResetGame()
+		return nil
+	})
+}
+
+// Do calls f while holding the game lock e.g. to render the board or read the scores. The game must not be used
+// after f returns.
+func (c *ConcurrentScrabulous) Do(f func(game *Scrabulous) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+	defer c.sync()
+	return f(c.game)
+}
+
+// sync tells the dispatcher about any queued events and starts or stops the steal timer to match the game.
+func (c *ConcurrentScrabulous) sync() {
+	if len(c.queue) > 0 {
+		select {
+		case c.notify <- struct{}{}:
+		default:
+		}
+	}
+
+	if c.game.GameState != StateStealing || c.ctx.Err() != nil {
+		c.stopTimer()
+		return
+	}
+	deadline := c.game.getClock().Now().Add(c.game.StealTime)
+	if c.game.PlaceWordAt != nil {
+		deadline = *c.game.PlaceWordAt
+	}
+	if c.timer == nil || !deadline.Equal(c.deadline) {
+		// a new window was opened or a restored game is part way through one
+		c.stopTimer()
+		c.startTimer(deadline)
+	}
+}
+
+func (c *ConcurrentScrabulous) startTimer(deadline time.Time) {
+	c.timerGen++
+	gen := c.timerGen
+	c.deadline = deadline
+	clock := c.game.getClock()
+	c.timer = clock.AfterFunc(deadline.Sub(clock.Now()), func() {
+		c.expire(gen)
+	})
+}
+
+func (c *ConcurrentScrabulous) stopTimer() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	c.timerGen++
+}
+
+// expire places the best pending word when the steal window closes.
+func (c *ConcurrentScrabulous) expire(gen int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.timerGen || c.ctx.Err() != nil {
+		// the timer was replaced or stopped after it fired
+		return
+	}
+	c.timer = nil
+	defer c.sync()
+	if c.game.GameState == StateStealing && len(c.game.PendingWords) > 0 {
+		// placing can only fail if there is no pending word
+		_ = c.game.PlacePendingWord()
+	}
+}
+
+// dispatch delivers queued events to the handler in order until the context is cancelled.
+func (c *ConcurrentScrabulous) dispatch() {
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-c.notify:
+		}
+		c.mu.Lock()
+		events := c.queue
+		c.queue = nil
+		c.mu.Unlock()
+		for _, e := range events {
+			c.handler(e)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package scrabble
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentScrabulous_placesBestWordWhenStealWindowCloses(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := NewFakeClock(time.Unix(0, 0))
+	placed := make(chan WordPlaced, 1)
+	game := NewConcurrentScrabulous(ctx, time.Minute, WithClock(clock), WithEventHandler(func(e Event) {
+		if e, ok := e.(WordPlaced); ok {
+			placed <- e
+		}
+	}))
+	if err := game.Do(func(game *Scrabulous) error {
+		game.Letters = []rune("CATSXYZ")
+		return nil
+	}); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for player, word := range map[string]string{"alice": "CAT", "bob": "CATS"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := game.CreatePendingWord(Placement{CellId: 112, Direction: Across}, word, player); err != nil {
+				t.Errorf("CreatePendingWord() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	clock.Advance(time.Second * 59)
+	select {
+	case e := <-placed:
+		t.Fatalf("word placed before the steal window closed: %+v", e)
+	default:
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case e := <-placed:
+		if e.Player != "bob" || e.Word != "CATS" {
+			t.Errorf("placed %s by %s, want CATS by bob", e.Word, e.Player)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("best word was not placed when the steal window closed")
+	}
+
+	cancel()
+	if err := game.PlacePendingWord(); err == nil {
+		t.Errorf("expected error using a cancelled game")
+	}
+}
+
+func TestConcurrentScrabulous_CreatePendingWord_atDeadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := NewFakeClock(time.Unix(0, 0))
+	placed := make(chan WordPlaced, 2)
+	game := NewConcurrentScrabulous(ctx, time.Minute, WithClock(clock), WithEventHandler(func(e Event) {
+		if e, ok := e.(WordPlaced); ok {
+			placed <- e
+		}
+	}))
+	if err := game.Do(func(game *Scrabulous) error {
+		game.Letters = []rune("CATSXYZ")
+		return nil
+	}); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if _, err := game.CreatePendingWord(Placement{CellId: 112, Direction: Across}, "CAT", "alice"); err != nil {
+		t.Fatalf("CreatePendingWord() error = %v", err)
+	}
+
+	// the window closes but the timer has not run yet
+	game.mu.Lock()
+	game.stopTimer()
+	game.mu.Unlock()
+	clock.Advance(time.Minute)
+
+	// the late word is checked against the letters dealt once CAT is placed so it may be rejected
+	_, _ = game.CreatePendingWord(Placement{CellId: 112, Direction: Across}, "CATS", "bob")
+	// run any timer started by the late word
+	clock.Advance(0)
+	select {
+	case e := <-placed:
+		if e.Player != "alice" || e.Word != "CAT" {
+			t.Errorf("placed %s by %s, want CAT by alice", e.Word, e.Player)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("pending word was not placed when the steal window closed")
+	}
+}
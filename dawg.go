@@ -0,0 +1,436 @@
+package scrabble
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Compiled graphs are stored as a flat list of edges. Each edge is packed into a uint32:
+//
+//	bits 0-4  letter (A=0 ... Z=25, or gaddagSeparator)
+//	bit  5    the path up to and including this edge is a word
+//	bit  6    this is the last edge leaving the node
+//	bits 7-31 index of the first edge of the child node (0 if the child has no edges)
+//
+// Edge 0 is unused so that an index of 0 can mean "no children". The root node's edges start at index 1.
+const (
+	edgeLetterMask = 0x1f
+	edgeTerminal   = 1 << 5
+	edgeLast       = 1 << 6
+	edgeChildShift = 7
+	maxGraphEdges  = 1 << (32 - edgeChildShift)
+
+	graphMagic         = "GSWG"
+	graphFormatVersion = 1
+)
+
+type graphKind uint8
+
+const (
+	graphKindDAWG   graphKind = 1
+	graphKindGADDAG graphKind = 2
+)
+
+// graph is a compiled, minimized word graph.
+type graph struct {
+	edges []uint32
+}
+
+// child returns the index of the edge leaving node with the given letter or 0 if there isn't one.
+func (g *graph) child(node uint32, letter byte) uint32 {
+	if node == 0 || int(node) >= len(g.edges) {
+		return 0
+	}
+	for i := node; int(i) < len(g.edges); i++ {
+		if byte(g.edges[i]&edgeLetterMask) == letter {
+			return i
+		}
+		if g.edges[i]&edgeLast != 0 {
+			break
+		}
+	}
+	return 0
+}
+
+func (g *graph) containsSymbols(symbols []byte) bool {
+	if len(symbols) == 0 {
+		return false
+	}
+	node := uint32(1)
+	var edge uint32
+	for _, s := range symbols {
+		edge = g.child(node, s)
+		if edge == 0 {
+			return false
+		}
+		node = g.edges[edge] >> edgeChildShift
+	}
+	return g.edges[edge]&edgeTerminal != 0
+}
+
+func (g *graph) writeTo(w io.Writer, kind graphKind) (int64, error) {
+	header := make([]byte, 0, 12)
+	header = append(header, graphMagic...)
+	header = append(header, graphFormatVersion, byte(kind), 0, 0)
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(g.edges)))
+
+	n, err := w.Write(header)
+	if err != nil {
+		return int64(n), err
+	}
+	body := make([]byte, 4*len(g.edges))
+	for i, e := range g.edges {
+		binary.LittleEndian.PutUint32(body[i*4:], e)
+	}
+	m, err := w.Write(body)
+	return int64(n + m), err
+}
+
+func (g *graph) saveFile(path string, kind graphKind) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(f)
+	if _, err := g.writeTo(bw, kind); err != nil {
+		f.Close()
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func loadGraphFile(path string, kind graphKind) (*graph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readGraph(bufio.NewReader(f), kind)
+}
+
+func readGraph(r io.Reader, kind graphKind) (*graph, error) {
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("failed to read graph header: %w", err)
+	}
+	if string(header[:4]) != graphMagic {
+		return nil, fmt.Errorf("not a compiled word graph")
+	}
+	if header[4] != graphFormatVersion {
+		return nil, fmt.Errorf("unsupported graph format version: %d", header[4])
+	}
+	if graphKind(header[5]) != kind {
+		return nil, fmt.Errorf("unexpected graph kind: %d", header[5])
+	}
+	numEdges := binary.LittleEndian.Uint32(header[8:])
+	if numEdges == 0 || numEdges > maxGraphEdges {
+		return nil, fmt.Errorf("invalid graph edge count: %d", numEdges)
+	}
+	body := make([]byte, 4*int(numEdges))
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("failed to read graph edges: %w", err)
+	}
+	g := &graph{edges: make([]uint32, numEdges)}
+	for i := range g.edges {
+		g.edges[i] = binary.LittleEndian.Uint32(body[i*4:])
+		if child := g.edges[i] >> edgeChildShift; child >= numEdges {
+			return nil, fmt.Errorf("graph edge %d has invalid child index %d", i, child)
+		}
+	}
+	return g, nil
+}
+
+// graphBuilder incrementally builds a minimal acyclic graph from lexicographically sorted input
+// (Daciuk et al. "Incremental Construction of Minimal Acyclic Finite-State Automata").
+type graphBuilder struct {
+	root      *buildNode
+	previous  []byte
+	unchecked []uncheckedEdge
+	minimized map[string]*buildNode
+	nextID    int
+}
+
+type buildNode struct {
+	id    int
+	final bool
+	edges []buildEdge
+}
+
+type buildEdge struct {
+	letter byte
+	node   *buildNode
+}
+
+type uncheckedEdge struct {
+	parent *buildNode
+	letter byte
+	child  *buildNode
+}
+
+func newGraphBuilder() *graphBuilder {
+	b := &graphBuilder{minimized: map[string]*buildNode{}}
+	b.root = b.newNode()
+	return b
+}
+
+func (b *graphBuilder) newNode() *buildNode {
+	b.nextID++
+	return &buildNode{id: b.nextID}
+}
+
+func (b *graphBuilder) insert(symbols []byte) error {
+	if len(symbols) == 0 {
+		return nil
+	}
+	if b.previous != nil && bytes.Compare(symbols, b.previous) <= 0 {
+		return fmt.Errorf("graph input must be sorted and unique")
+	}
+
+	common := 0
+	for common < len(symbols) && common < len(b.previous) && symbols[common] == b.previous[common] {
+		common++
+	}
+	b.minimize(common)
+
+	node := b.root
+	if len(b.unchecked) > 0 {
+		node = b.unchecked[len(b.unchecked)-1].child
+	}
+	for _, s := range symbols[common:] {
+		next := b.newNode()
+		node.edges = append(node.edges, buildEdge{letter: s, node: next})
+		b.unchecked = append(b.unchecked, uncheckedEdge{parent: node, letter: s, child: next})
+		node = next
+	}
+	node.final = true
+	b.previous = slices.Clone(symbols)
+	return nil
+}
+
+func (b *graphBuilder) minimize(downTo int) {
+	for i := len(b.unchecked) - 1; i >= downTo; i-- {
+		edge := b.unchecked[i]
+		key := edge.child.key()
+		if existing, ok := b.minimized[key]; ok {
+			edge.parent.edges[len(edge.parent.edges)-1].node = existing
+		} else {
+			b.minimized[key] = edge.child
+		}
+	}
+	b.unchecked = b.unchecked[:downTo]
+}
+
+func (n *buildNode) key() string {
+	sb := strings.Builder{}
+	if n.final {
+		sb.WriteByte('1')
+	} else {
+		sb.WriteByte('0')
+	}
+	for _, e := range n.edges {
+		sb.WriteByte('_')
+		sb.WriteByte(e.letter)
+		sb.WriteString(strconv.Itoa(e.node.id))
+	}
+	return sb.String()
+}
+
+// compile flattens the built nodes into the packed edge format.
+func (b *graphBuilder) compile() (*graph, error) {
+	b.minimize(0)
+
+	// assign each node with children a contiguous block of edges
+	positions := map[*buildNode]uint32{}
+	order := []*buildNode{}
+	next := uint32(1)
+	var assign func(n *buildNode)
+	assign = func(n *buildNode) {
+		if len(n.edges) == 0 {
+			return
+		}
+		if _, ok := positions[n]; ok {
+			return
+		}
+		positions[n] = next
+		next += uint32(len(n.edges))
+		order = append(order, n)
+		for _, e := range n.edges {
+			assign(e.node)
+		}
+	}
+	assign(b.root)
+	if next > maxGraphEdges {
+		return nil, fmt.Errorf("graph too large: %d edges", next)
+	}
+
+	g := &graph{edges: make([]uint32, next)}
+	for _, n := range order {
+		pos := positions[n]
+		for i, e := range n.edges {
+			packed := uint32(e.letter) | positions[e.node]<<edgeChildShift
+			if e.node.final {
+				packed |= edgeTerminal
+			}
+			if i == len(n.edges)-1 {
+				packed |= edgeLast
+			}
+			g.edges[pos+uint32(i)] = packed
+		}
+	}
+	return g, nil
+}
+
+// buildGraph compiles the given symbol sequences. The input is sorted and de-duplicated in place.
+func buildGraph(sequences [][]byte) (*graph, error) {
+	slices.SortFunc(sequences, bytes.Compare)
+	sequences = slices.CompactFunc(sequences, bytes.Equal)
+
+	builder := newGraphBuilder()
+	for _, v := range sequences {
+		if err := builder.insert(v); err != nil {
+			return nil, err
+		}
+	}
+	return builder.compile()
+}
+
+// wordToSymbols converts a word to graph symbols, returning false if it contains anything except the letters A-Z.
+func wordToSymbols(word string) ([]byte, bool) {
+	symbols := make([]byte, 0, len(word))
+	for _, r := range strings.ToUpper(word) {
+		if r < 'A' || r > 'Z' {
+			return nil, false
+		}
+		symbols = append(symbols, byte(r-'A'))
+	}
+	return symbols, true
+}
+
+// DAWG is a compressed directed acyclic word graph. It implements Lexicon.
+type DAWG struct {
+	graph *graph
+}
+
+// NewDAWG compiles a DAWG from the given words. Words may be in any order or case.
+func NewDAWG(words []string) (*DAWG, error) {
+	encoded := make([][]byte, 0, len(words))
+	for _, w := range words {
+		symbols, ok := wordToSymbols(w)
+		if !ok {
+			return nil, fmt.Errorf("invalid word: %s", w)
+		}
+		encoded = append(encoded, symbols)
+	}
+	g, err := buildGraph(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return &DAWG{graph: g}, nil
+}
+
+func (d *DAWG) Contains(word string) bool {
+	symbols, ok := wordToSymbols(word)
+	if !ok {
+		return false
+	}
+	return d.graph.containsSymbols(symbols)
+}
+
+// WriteTo serializes the compiled graph so it can be loaded with ReadDAWG.
+func (d *DAWG) WriteTo(w io.Writer) (int64, error) {
+	return d.graph.writeTo(w, graphKindDAWG)
+}
+
+// SaveFile writes the compiled graph to the given path.
+func (d *DAWG) SaveFile(path string) error {
+	return d.graph.saveFile(path, graphKindDAWG)
+}
+
+// ReadDAWG loads a graph previously written with WriteTo.
+func ReadDAWG(r io.Reader) (*DAWG, error) {
+	g, err := readGraph(r, graphKindDAWG)
+	if err != nil {
+		return nil, err
+	}
+	return &DAWG{graph: g}, nil
+}
+
+func LoadDAWGFile(path string) (*DAWG, error) {
+	g, err := loadGraphFile(path, graphKindDAWG)
+	if err != nil {
+		return nil, err
+	}
+	return &DAWG{graph: g}, nil
+}
+
+// LoadWordList compiles a DAWG from a newline separated list of words. The input may be gzip compressed.
+// Only the first field of each line is used so lists that include definitions are supported. Empty lines
+// and lines starting with # are ignored.
+func LoadWordList(r io.Reader) (*DAWG, error) {
+	words, err := readWordList(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewDAWG(words)
+}
+
+func LoadWordListFile(path string) (*DAWG, error) {
+	words, err := readWordListFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewDAWG(words)
+}
+
+func readWordListFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readWordList(f)
+}
+
+func readWordList(r io.Reader) ([]string, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip word list: %w", err)
+		}
+		defer gz.Close()
+		br = bufio.NewReader(gz)
+	}
+
+	words := []string{}
+	scanner := bufio.NewScanner(br)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word := strings.Fields(line)[0]
+		if _, ok := wordToSymbols(word); !ok {
+			return nil, fmt.Errorf("invalid word on line %d: %s", lineNum, word)
+		}
+		words = append(words, strings.ToUpper(word))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read word list: %w", err)
+	}
+	return words, nil
+}
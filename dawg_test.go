@@ -0,0 +1,243 @@
+package scrabble
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"slices"
+	"testing"
+)
+
+func TestNewDAWG(t *testing.T) {
+	tests := []struct {
+		name    string
+		words   []string
+		want    []string
+		notWant []string
+		wantErr bool
+	}{
+		{
+			name:    "words in any order and case",
+			words:   []string{"cats", "CAT", "Dog", "ca"},
+			want:    []string{"CA", "CAT", "CATS", "DOG", "cat", "dog"},
+			notWant: []string{"C", "CATSS", "DO", "DOGS", "", "CA T"},
+		},
+		{
+			name:    "duplicate words",
+			words:   []string{"CAT", "cat", "CAT", "DOG", "DOG"},
+			want:    []string{"CAT", "DOG"},
+			notWant: []string{"CATCAT", "CA"},
+		},
+		{
+			name:    "non-alphabetic word",
+			words:   []string{"CAT", "DO-G"},
+			wantErr: true,
+		},
+		{
+			name:    "blank tile in word",
+			words:   []string{"C_T"},
+			wantErr: true,
+		},
+		{
+			name:    "non-ascii word",
+			words:   []string{"CAFÉ"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dawg, err := NewDAWG(tt.words)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewDAWG() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for _, w := range tt.want {
+				if !dawg.Contains(w) {
+					t.Errorf("Contains(%q) = false, want true", w)
+				}
+			}
+			for _, w := range tt.notWant {
+				if dawg.Contains(w) {
+					t.Errorf("Contains(%q) = true, want false", w)
+				}
+			}
+		})
+	}
+}
+
+func TestNewDAWG_minimized(t *testing.T) {
+	tests := []struct {
+		name      string
+		words     []string
+		wantEdges int
+	}{
+		{
+			// the unused edge 0, C/M/R from the root then a single shared A-T-S chain
+			name:      "shared suffix",
+			words:     []string{"CATS", "MATS", "RATS"},
+			wantEdges: 1 + 3 + 3,
+		},
+		{
+			// CAT and CATS end on different nodes so only the prefix is shared
+			name:      "shared prefix",
+			words:     []string{"CAT", "CATS", "CATTLE"},
+			wantEdges: 1 + 1 + 1 + 1 + 2 + 1 + 1,
+		},
+		{
+			// A, CA and SCA all lead to the node for the T so only the S-C-A chain is extra
+			name:      "words that are suffixes of each other",
+			words:     []string{"AT", "CAT", "SCAT"},
+			wantEdges: 1 + 3 + 1 + 1 + 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dawg, err := NewDAWG(tt.words)
+			if err != nil {
+				t.Fatalf("NewDAWG() error = %v", err)
+			}
+			if got := len(dawg.graph.edges); got != tt.wantEdges {
+				t.Errorf("graph has %d edges, want %d", got, tt.wantEdges)
+			}
+			for _, w := range tt.words {
+				if !dawg.Contains(w) {
+					t.Errorf("Contains(%q) = false, want true", w)
+				}
+			}
+		})
+	}
+}
+
+func TestDAWG_WriteTo(t *testing.T) {
+	words := []string{"A", "AA", "AB", "ABA", "CAT", "CATS", "DOG", "DOGS", "ZZZ"}
+	dawg, err := NewDAWG(words)
+	if err != nil {
+		t.Fatalf("NewDAWG() error = %v", err)
+	}
+	buf := &bytes.Buffer{}
+	n, err := dawg.WriteTo(buf)
+	if err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo() = %d, wrote %d bytes", n, buf.Len())
+	}
+
+	loaded, err := ReadDAWG(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadDAWG() error = %v", err)
+	}
+	if !slices.Equal(loaded.graph.edges, dawg.graph.edges) {
+		t.Errorf("loaded graph differs from the written graph")
+	}
+	for _, w := range words {
+		if !loaded.Contains(w) {
+			t.Errorf("loaded Contains(%q) = false, want true", w)
+		}
+	}
+	for _, w := range []string{"B", "CA", "DOGSS", "ZZ"} {
+		if loaded.Contains(w) {
+			t.Errorf("loaded Contains(%q) = true, want false", w)
+		}
+	}
+}
+
+func TestReadDAWG_invalid(t *testing.T) {
+	dawg, err := NewDAWG([]string{"CAT", "CATS", "DOG"})
+	if err != nil {
+		t.Fatalf("NewDAWG() error = %v", err)
+	}
+	buf := &bytes.Buffer{}
+	if _, err := dawg.WriteTo(buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	valid := buf.Bytes()
+
+	gaddag, err := NewGADDAG([]string{"CAT"})
+	if err != nil {
+		t.Fatalf("NewGADDAG() error = %v", err)
+	}
+	gaddagBuf := &bytes.Buffer{}
+	if _, err := gaddag.WriteTo(gaddagBuf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+
+	modified := func(f func(b []byte)) []byte {
+		b := bytes.Clone(valid)
+		f(b)
+		return b
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: []byte{}},
+		{name: "bad magic", data: modified(func(b []byte) { copy(b, "NOPE") })},
+		{name: "unsupported version", data: modified(func(b []byte) { b[4] = graphFormatVersion + 1 })},
+		{name: "gaddag", data: gaddagBuf.Bytes()},
+		{name: "zero edges", data: modified(func(b []byte) { binary.LittleEndian.PutUint32(b[8:], 0) })},
+		{name: "too many edges", data: modified(func(b []byte) { binary.LittleEndian.PutUint32(b[8:], 0xffffffff) })},
+		{name: "more edges than data", data: modified(func(b []byte) { binary.LittleEndian.PutUint32(b[8:], uint32(len(valid))) })},
+		{name: "child out of range", data: modified(func(b []byte) { binary.LittleEndian.PutUint32(b[16:], 0xffffffff) })},
+		{name: "plain word list", data: []byte("CAT\nDOG\n")},
+	}
+	for i := 1; i < len(valid); i += 3 {
+		tests = append(tests, struct {
+			name string
+			data []byte
+		}{name: "truncated", data: valid[:i]})
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadDAWG(bytes.NewReader(tt.data)); err == nil {
+				t.Errorf("ReadDAWG() expected error")
+			}
+		})
+	}
+}
+
+func TestLoadWordList(t *testing.T) {
+	list := "# comment\n\ncat a small animal\n  DOG\nCats\nDog\n"
+	gzipped := &bytes.Buffer{}
+	gz := gzip.NewWriter(gzipped)
+	if _, err := gz.Write([]byte(list)); err != nil {
+		t.Fatalf("failed to gzip word list: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("failed to gzip word list: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{name: "plain", data: []byte(list)},
+		{name: "gzip", data: gzipped.Bytes()},
+		{name: "non-alphabetic word", data: []byte("CAT\nDOG2\n"), wantErr: true},
+		{name: "corrupt gzip", data: gzipped.Bytes()[:gzipped.Len()/2], wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dawg, err := LoadWordList(bytes.NewReader(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadWordList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for _, w := range []string{"CAT", "CATS", "DOG"} {
+				if !dawg.Contains(w) {
+					t.Errorf("Contains(%q) = false, want true", w)
+				}
+			}
+			for _, w := range []string{"A", "SMALL", "ANIMAL", "COMMENT", "XXX"} {
+				if dawg.Contains(w) {
+					t.Errorf("Contains(%q) = true, want false", w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package scrabble
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventGameStarted     EventType = "game_started"
+	EventPlayerJoined    EventType = "player_joined"
+	EventTilesDrawn      EventType = "tiles_drawn"
+	EventTilesReturned   EventType = "tiles_returned"
+	EventWordProposed    EventType = "word_proposed"
+	EventWordStolen      EventType = "word_stolen"
+	EventWordPlaced      EventType = "word_placed"
+	EventTilesExchanged  EventType = "tiles_exchanged"
+	EventTurnPassed      EventType = "turn_passed"
+	EventPlayWithdrawn   EventType = "play_withdrawn"
+	EventChallengeFailed EventType = "challenge_failed"
+	EventScoreAdjusted   EventType = "score_adjusted"
+	EventTurnUndone      EventType = "turn_undone"
+	EventTurnRedone      EventType = "turn_redone"
+	EventGameRestored    EventType = "game_restored"
+	EventGameEnded       EventType = "game_ended"
+
+	EventStealWindowOpened EventType = "steal_window_opened"
+	EventStealWindowClosed EventType = "steal_window_closed"
+	EventTurnChanged       EventType = "turn_changed"
+	EventBagLow            EventType = "bag_low"
+	EventGameComplete      EventType = "game_complete"
+)
+
+// Event is a single change to the state of a game. Every change a game makes is recorded as an event so the game
+// can be rebuilt from its events using Replay. Changes made by writing to a game's fields directly are not
+// recorded.
+type Event interface {
+	EventType() EventType
+}
+
+// GameStarted resets the game to an empty board and a full bag.
+type GameStarted struct {
+	ChallengeRule ChallengeRule `json:"challenge_rule,omitempty"`
+	StealTime     time.Duration `json:"steal_time,omitempty"`
+}
+
+func (GameStarted) EventType() EventType { return EventGameStarted }
+
+type PlayerJoined struct {
+	Player string `json:"player"`
+	// Bot is the name of the player's bot strategy if they are a bot.
+	Bot string `json:"bot,omitempty"`
+
+	// strategy is the instance given to AddBot, events decoded from a log only have the name.
+	strategy BotStrategy
+}
+
+func (PlayerJoined) EventType() EventType { return EventPlayerJoined }
+
+// TilesDrawn moves tiles from the bag to a player's rack. In Scrabulous the rack is shared so Player is empty.
+type TilesDrawn struct {
+	Player string `json:"player,omitempty"`
+	Tiles  string `json:"tiles"`
+}
+
+func (TilesDrawn) EventType() EventType { return EventTilesDrawn }
+
+// TilesReturned moves tiles from a player's rack back to the bag.
+type TilesReturned struct {
+	Player string `json:"player,omitempty"`
+	Tiles  string `json:"tiles"`
+}
+
+func (TilesReturned) EventType() EventType { return EventTilesReturned }
+
+// WordProposed is the first word submitted in Scrabulous, it opens the steal window.
+type WordProposed struct {
+	Player    string    `json:"player"`
+	Placement Placement `json:"placement"`
+	Word      string    `json:"word"`
+	Score     int       `json:"score"`
+	At        time.Time `json:"at"`
+}
+
+func (WordProposed) EventType() EventType { return EventWordProposed }
+
+// WordStolen is a higher scoring word submitted during the Scrabulous steal window.
+type WordStolen struct {
+	Player    string    `json:"player"`
+	From      string    `json:"from"`
+	Placement Placement `json:"placement"`
+	Word      string    `json:"word"`
+	Score     int       `json:"score"`
+	At        time.Time `json:"at"`
+}
+
+func (WordStolen) EventType() EventType { return EventWordStolen }
+
+// WordPlaced puts a word on the board. In Classic games the tiles the player drew afterwards are included, in
+// Scrabulous the shared rack is refilled by separate events.
+type WordPlaced struct {
+	Player    string    `json:"player"`
+	Placement Placement `json:"placement"`
+	Word      string    `json:"word"`
+	Score     int       `json:"score"`
+	// Explanation is the breakdown of the score as given by PlacementResult.ExplainScore.
+	Explanation []string `json:"explanation,omitempty"`
+	Drawn       string   `json:"drawn,omitempty"`
+}
+
+func (WordPlaced) EventType() EventType { return EventWordPlaced }
+
+type TilesExchanged struct {
+	Player string `json:"player"`
+	Tiles  string `json:"tiles"`
+	Drawn  string `json:"drawn"`
+}
+
+func (TilesExchanged) EventType() EventType { return EventTilesExchanged }
+
+type TurnPassed struct {
+	Player string `json:"player"`
+}
+
+func (TurnPassed) EventType() EventType { return EventTurnPassed }
+
+// PlayWithdrawn removes the last play after a successful challenge.
+type PlayWithdrawn struct {
+	Player string `json:"player"`
+}
+
+func (PlayWithdrawn) EventType() EventType { return EventPlayWithdrawn }
+
+// ChallengeFailed records a challenge against a valid play and the penalty the challenge rule applied.
+type ChallengeFailed struct {
+	Challenger string `json:"challenger,omitempty"`
+	Challenged string `json:"challenged"`
+	// Bonus are the points awarded to the challenged player.
+	Bonus int `json:"bonus,omitempty"`
+	// TurnLost is true if the challenger lost their turn.
+	TurnLost bool `json:"turn_lost,omitempty"`
+}
+
+func (ChallengeFailed) EventType() EventType { return EventChallengeFailed }
+
+// ScoreAdjusted changes a player's score outside a turn e.g. a time penalty recorded in an imported game.
+type ScoreAdjusted struct {
+	Player string `json:"player"`
+	Points int    `json:"points"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func (ScoreAdjusted) EventType() EventType { return EventScoreAdjusted }
+
+type TurnUndone struct{}
+
+func (TurnUndone) EventType() EventType { return EventTurnUndone }
+
+type TurnRedone struct{}
+
+func (TurnRedone) EventType() EventType { return EventTurnRedone }
+
+// GameRestored replaces the whole game state with a snapshot. Only the snapshot for the type of game is set.
+type GameRestored struct {
+	Classic    *ClassicSnapshot    `json:"classic,omitempty"`
+	Scrabulous *ScrabulousSnapshot `json:"scrabulous,omitempty"`
+}
+
+func (GameRestored) EventType() EventType { return EventGameRestored }
+
+type GameEnded struct {
+	Reason EndReason `json:"reason"`
+}
+
+func (GameEnded) EventType() EventType { return EventGameEnded }
+
+// StealWindowOpened, StealWindowClosed, TurnChanged, BagLow and GameComplete are only sent to subscribers. They
+// are not recorded as they follow from the recorded events.
+
+type StealWindowOpened struct {
+	Player   string    `json:"player"`
+	Word     string    `json:"word"`
+	Score    int       `json:"score"`
+	ClosesAt time.Time `json:"closes_at"`
+}
+
+func (StealWindowOpened) EventType() EventType { return EventStealWindowOpened }
+
+// StealWindowClosed gives the word that was placed when the window closed. Player is empty if no word was placed
+// e.g. the game was reset.
+type StealWindowClosed struct {
+	Player string `json:"player,omitempty"`
+	Word   string `json:"word,omitempty"`
+	Score  int    `json:"score,omitempty"`
+}
+
+func (StealWindowClosed) EventType() EventType { return EventStealWindowClosed }
+
+type TurnChanged struct {
+	Player   string `json:"player"`
+	Previous string `json:"previous"`
+}
+
+func (TurnChanged) EventType() EventType { return EventTurnChanged }
+
+// BagLow is sent whenever tiles are drawn while fewer than NumPlayerLetters remain in the bag.
+type BagLow struct {
+	Remaining int `json:"remaining"`
+}
+
+func (BagLow) EventType() EventType { return EventBagLow }
+
+type GameComplete struct {
+	Result *GameResult `json:"result"`
+}
+
+func (GameComplete) EventType() EventType { return EventGameComplete }
+
+type eventEnvelope struct {
+	Type EventType       `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// MarshalEvent encodes an event as JSON including its type so it can be decoded with UnmarshalEvent.
+func MarshalEvent(e Event) ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(eventEnvelope{Type: e.EventType(), Data: data})
+}
+
+func UnmarshalEvent(data []byte) (Event, error) {
+	envelope := eventEnvelope{}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+	var e Event
+	switch envelope.Type {
+	case EventGameStarted:
+		e = &GameStarted{}
+	case EventPlayerJoined:
+		e = &PlayerJoined{}
+	case EventTilesDrawn:
+		e = &TilesDrawn{}
+	case EventTilesReturned:
+		e = &TilesReturned{}
+	case EventWordProposed:
+		e = &WordProposed{}
+	case EventWordStolen:
+		e = &WordStolen{}
+	case EventWordPlaced:
+		e = &WordPlaced{}
+	case EventTilesExchanged:
+		e = &TilesExchanged{}
+	case EventTurnPassed:
+		e = &TurnPassed{}
+	case EventPlayWithdrawn:
+		e = &PlayWithdrawn{}
+	case EventChallengeFailed:
+		e = &ChallengeFailed{}
+	case EventScoreAdjusted:
+		e = &ScoreAdjusted{}
+	case EventTurnUndone:
+		e = &TurnUndone{}
+	case EventTurnRedone:
+		e = &TurnRedone{}
+	case EventGameRestored:
+		e = &GameRestored{}
+	case EventGameEnded:
+		e = &GameEnded{}
+	case EventStealWindowOpened:
+		e = &StealWindowOpened{}
+	case EventStealWindowClosed:
+		e = &StealWindowClosed{}
+	case EventTurnChanged:
+		e = &TurnChanged{}
+	case EventBagLow:
+		e = &BagLow{}
+	case EventGameComplete:
+		e = &GameComplete{}
+	default:
+		return nil, fmt.Errorf("unknown event type: %s", envelope.Type)
+	}
+	if err := json.Unmarshal(envelope.Data, e); err != nil {
+		return nil, fmt.Errorf("invalid %s event: %w", envelope.Type, err)
+	}
+	return derefEvent(e), nil
+}
+
+// derefEvent returns the event value so decoded events have the same types as the ones games record.
+func derefEvent(e Event) Event {
+	switch v := e.(type) {
+	case *GameStarted:
+		return *v
+	case *PlayerJoined:
+		return *v
+	case *TilesDrawn:
+		return *v
+	case *TilesReturned:
+		return *v
+	case *WordProposed:
+		return *v
+	case *WordStolen:
+		return *v
+	case *WordPlaced:
+		return *v
+	case *TilesExchanged:
+		return *v
+	case *TurnPassed:
+		return *v
+	case *PlayWithdrawn:
+		return *v
+	case *ChallengeFailed:
+		return *v
+	case *ScoreAdjusted:
+		return *v
+	case *TurnUndone:
+		return *v
+	case *TurnRedone:
+		return *v
+	case *GameRestored:
+		return *v
+	case *GameEnded:
+		return *v
+	case *StealWindowOpened:
+		return *v
+	case *StealWindowClosed:
+		return *v
+	case *TurnChanged:
+		return *v
+	case *BagLow:
+		return *v
+	case *GameComplete:
+		return *v
+	}
+	return e
+}
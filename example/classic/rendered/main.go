@@ -18,25 +18,25 @@ func main() {
 		panic(err)
 	}
 	game.Players[0].Letters = []rune{'F', 'O', 'O', 'F'}
-	if err := game.PlaceWord(scrabble.MustParsePlacement("A113"), "foof"); err != nil {
+	if err := game.PlaceWord(scrabble.MustParsePlacement("A113"), "FOOF"); err != nil {
 		panic(err)
 	}
 	fmt.Println("Letters remaining: ", scrabble.RuneSliceAsString(game.Players[0].Letters))
 	fmt.Println("Score: ", game.Players[0].Score)
 
 	game.Players[1].Letters = []rune{'F', 'O', 'O', 'F'}
-	if err := game.PlaceWord(scrabble.MustParsePlacement("D116"), "foof"); err != nil {
+	if err := game.PlaceWord(scrabble.MustParsePlacement("D116"), "FOOF"); err != nil {
 		panic(err)
 	}
 
 	//game.Players[2].Letters = []rune{'F', 'O', 'O', 'F'}
-	//if err := game.PlaceWord(scrabble.MustParsePlacement("A145"), "foof"); err != nil {
+	//if err := game.PlaceWord(scrabble.MustParsePlacement("A145"), "FOOF"); err != nil {
 	//	panic(err)
 	//}
 
 	scrabble.PrintGame(game, os.Stdout)
 
-	canvas, err := scrabble.RenderClassicPNG(game, 1500, 1000)
+	canvas, err := scrabble.RenderClassicPNG(game, 1500, 1000, scrabble.WithTileTracker())
 	if err != nil {
 		panic(err)
 	}
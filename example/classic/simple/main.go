@@ -14,19 +14,19 @@ func main() {
 		panic(err)
 	}
 	game.Players[0].Letters = []rune{'F', 'O', 'O', 'F'}
-	if err := game.PlaceWord(scrabble.MustParsePlacement("A113"), "foof"); err != nil {
+	if err := game.PlaceWord(scrabble.MustParsePlacement("A113"), "FOOF"); err != nil {
 		panic(err)
 	}
 	fmt.Println("Letters remaining: ", scrabble.RuneSliceAsString(game.Players[0].Letters))
 	fmt.Println("Score: ", game.Players[0].Score)
 
 	game.Players[0].Letters = []rune{'F', 'O', 'O', 'F'}
-	if err := game.PlaceWord(scrabble.MustParsePlacement("D116"), "foof"); err != nil {
+	if err := game.PlaceWord(scrabble.MustParsePlacement("D116"), "FOOF"); err != nil {
 		panic(err)
 	}
 
 	game.Players[0].Letters = []rune{'F', 'O', 'O', 'F'}
-	if err := game.PlaceWord(scrabble.MustParsePlacement("A145"), "foof"); err != nil {
+	if err := game.PlaceWord(scrabble.MustParsePlacement("A145"), "FOOF"); err != nil {
 		panic(err)
 	}
 
@@ -9,7 +9,7 @@ func main() {
 	game := scrabble.NewScrabulousGame(time.Minute * 5)
 
 	game.Letters = []rune{'F', 'O', 'O', 'F'}
-	if _, err := game.CreatePendingWord(scrabble.MustParsePlacement("A113"), "foof", "player 1"); err != nil {
+	if _, err := game.CreatePendingWord(scrabble.MustParsePlacement("A113"), "FOOF", "player 1"); err != nil {
 		panic(err)
 	}
 
@@ -23,7 +23,7 @@ func main() {
 	}
 
 	game.Letters = []rune{'F', 'O', 'O', 'F', 'S'}
-	if _, err := game.CreatePendingWord(scrabble.MustParsePlacement("D57"), "foofs", "player 2"); err != nil {
+	if _, err := game.CreatePendingWord(scrabble.MustParsePlacement("D57"), "FOOFS", "player 2"); err != nil {
 		panic(err)
 	}
 	//for _, v := range game.PendingWords {
@@ -0,0 +1,98 @@
+package scrabble
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// gaddagSeparator marks the point in a GADDAG path where the reversed prefix ends and the suffix begins.
+const gaddagSeparator byte = 26
+
+// GADDAG is a word graph that stores every word once for each letter it can be built from, allowing words to be
+// grown in both directions from a letter on the board (Gordon, "A Faster Scrabble Move Generation Algorithm").
+// Each word is stored as REV(prefix)+separator+suffix for every non-empty prefix. It implements Lexicon.
+type GADDAG struct {
+	graph *graph
+}
+
+// NewGADDAG compiles a GADDAG from the given words. Words may be in any order or case.
+func NewGADDAG(words []string) (*GADDAG, error) {
+	paths := make([][]byte, 0, len(words)*8)
+	for _, w := range words {
+		symbols, ok := wordToSymbols(w)
+		if !ok {
+			return nil, fmt.Errorf("invalid word: %s", w)
+		}
+		for i := 1; i <= len(symbols); i++ {
+			path := make([]byte, 0, len(symbols)+1)
+			prefix := slices.Clone(symbols[:i])
+			slices.Reverse(prefix)
+			path = append(path, prefix...)
+			if i < len(symbols) {
+				path = append(path, gaddagSeparator)
+				path = append(path, symbols[i:]...)
+			}
+			paths = append(paths, path)
+		}
+	}
+	g, err := buildGraph(paths)
+	if err != nil {
+		return nil, err
+	}
+	return &GADDAG{graph: g}, nil
+}
+
+// Contains checks for the word by following its fully reversed path.
+func (d *GADDAG) Contains(word string) bool {
+	symbols, ok := wordToSymbols(word)
+	if !ok {
+		return false
+	}
+	slices.Reverse(symbols)
+	return d.graph.containsSymbols(symbols)
+}
+
+// WriteTo serializes the compiled graph so it can be loaded with ReadGADDAG.
+func (d *GADDAG) WriteTo(w io.Writer) (int64, error) {
+	return d.graph.writeTo(w, graphKindGADDAG)
+}
+
+// SaveFile writes the compiled graph to the given path.
+func (d *GADDAG) SaveFile(path string) error {
+	return d.graph.saveFile(path, graphKindGADDAG)
+}
+
+// ReadGADDAG loads a graph previously written with WriteTo.
+func ReadGADDAG(r io.Reader) (*GADDAG, error) {
+	g, err := readGraph(r, graphKindGADDAG)
+	if err != nil {
+		return nil, err
+	}
+	return &GADDAG{graph: g}, nil
+}
+
+func LoadGADDAGFile(path string) (*GADDAG, error) {
+	g, err := loadGraphFile(path, graphKindGADDAG)
+	if err != nil {
+		return nil, err
+	}
+	return &GADDAG{graph: g}, nil
+}
+
+// LoadGADDAGWordList compiles a GADDAG from a word list in the same format accepted by LoadWordList.
+func LoadGADDAGWordList(r io.Reader) (*GADDAG, error) {
+	words, err := readWordList(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewGADDAG(words)
+}
+
+func LoadGADDAGWordListFile(path string) (*GADDAG, error) {
+	words, err := readWordListFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewGADDAG(words)
+}
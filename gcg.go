@@ -0,0 +1,320 @@
+package scrabble
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ExportGCG writes the game history in the GCG format used by most Scrabble annotators
+// (https://www.poslfit.com/scrabble/gcg/). Played through letters are written as '.' and blanks as lower case
+// letters in words or '?' on racks.
+func ExportGCG(g *Classic, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "#character-encoding UTF-8")
+
+	nicks := make(map[string]string, len(g.Players))
+	used := make(map[string]bool, len(g.Players))
+	for i, p := range g.Players {
+		nick := gcgNickname(p.Name)
+		for used[nick] {
+			// names that only differ in whitespace e.g. "a b" and "a_b" would otherwise share a nickname
+			nick = fmt.Sprintf("%s_%d", nick, i+1)
+		}
+		used[nick] = true
+		nicks[p.Name] = nick
+		fmt.Fprintf(bw, "#player%d %s %s\n", i+1, nick, p.Name)
+	}
+
+	totals := map[string]int{}
+	writeMove := func(player string, rack []rune, play string, score int) {
+		totals[player] += score
+		fields := []string{play, fmt.Sprintf("%+d", score), strconv.Itoa(totals[player])}
+		if len(rack) > 0 {
+			// the rack is left out when it isn't known e.g. for the tiles a player gets for going out
+			fields = append([]string{gcgTiles(rack)}, fields...)
+		}
+		fmt.Fprintf(bw, ">%s: %s\n", nicks[player], strings.Join(fields, " "))
+	}
+
+	// the moves are replayed on an empty board to find the letters each play went through
+	board := NewBoard(len(g.Board))
+	tilesPlaced := false
+	for _, m := range g.History {
+		switch m.Type {
+		case TurnPlay, TurnWithdrawn:
+			result, err := board.isValidWordPlacement(m.Placement, m.Word, !tilesPlaced)
+			if err != nil {
+				return fmt.Errorf("failed to replay %s %s: %w", m.Placement.String(), m.Word, err)
+			}
+			word := []rune(m.Word)
+			for i, c := range result.Cells {
+				if existing := board.GetCell(int64(c.Index), CellAny); existing != nil && !existing.Empty() {
+					word[i] = '.'
+				}
+			}
+			coordinate, err := m.Placement.coordinate(len(board))
+			if err != nil {
+				return err
+			}
+			writeMove(m.Player, m.RackBefore, fmt.Sprintf("%s %s", coordinate, string(word)), result.Score())
+			if m.Type == TurnWithdrawn {
+				writeMove(m.Player, m.RackBefore, "--", -result.Score())
+				continue
+			}
+			if _, err := board.placeWord(m.Placement, m.Word); err != nil {
+				return err
+			}
+			tilesPlaced = true
+		case TurnExchange:
+			writeMove(m.Player, m.RackBefore, "-"+gcgTiles(m.Exchanged), 0)
+		case TurnPass, TurnChallengeLost:
+			writeMove(m.Player, m.RackBefore, "-", 0)
+		case TurnChallengeBonus:
+			writeMove(m.Player, m.RackBefore, "(challenge)", m.Score)
+		}
+	}
+
+	if g.Complete {
+		for _, p := range g.Players {
+			adjustment := g.endAdjustment(p, g.EndReason)
+			switch {
+			case g.EndReason == EndPlayerWentOut && len(p.Letters) == 0:
+				opponentTiles := []rune{}
+				for _, o := range g.Players {
+					opponentTiles = append(opponentTiles, o.Letters...)
+				}
+				writeMove(p.Name, nil, fmt.Sprintf("(%s)", gcgTiles(opponentTiles)), adjustment)
+			case g.EndReason == EndScorelessTurns && len(p.Letters) > 0:
+				writeMove(p.Name, p.Letters, fmt.Sprintf("(%s)", gcgTiles(p.Letters)), adjustment)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// ImportGCG reconstructs a Classic game from a GCG file. Plays are not checked against the game lexicon as the
+// record is taken to be correct, but scores are checked against the scores in the file. Racks given in the file
+// are drawn from the bag so the tiles left in the bag match the original game as closely as possible.
+func ImportGCG(r io.Reader, opts ...GameOption) (*Classic, error) {
+	g := NewClassicGame(opts...)
+	lexicon := g.lexicon
+	g.lexicon = nil
+	defer func() {
+		g.lexicon = lexicon
+	}()
+
+	players := map[string]int{}
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		var err error
+		switch {
+		case strings.HasPrefix(line, "#player"):
+			err = importGCGPlayer(g, players, line)
+		case strings.HasPrefix(line, ">"):
+			err = importGCGMove(g, players, line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func importGCGPlayer(g *Classic, players map[string]int, line string) error {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return fmt.Errorf("invalid player: %s", line)
+	}
+	if _, ok := players[fields[1]]; ok {
+		return fmt.Errorf("duplicate player: %s", fields[1])
+	}
+	name := fields[1]
+	if len(fields) > 2 {
+		name = strings.Join(fields[2:], " ")
+	}
+	if err := g.AddPlayer(name); err != nil {
+		return err
+	}
+	players[fields[1]] = len(g.Players) - 1
+	return nil
+}
+
+func importGCGMove(g *Classic, players map[string]int, line string) error {
+	nick, rest, ok := strings.Cut(strings.TrimPrefix(line, ">"), ":")
+	if !ok {
+		return fmt.Errorf("invalid move: %s", line)
+	}
+	playerIdx, ok := players[nick]
+	if !ok {
+		return fmt.Errorf("unknown player: %s", nick)
+	}
+	player := g.Players[playerIdx]
+
+	fields := strings.Fields(rest)
+	if len(fields) < 3 {
+		return fmt.Errorf("invalid move: %s", line)
+	}
+	score, err := strconv.Atoi(fields[len(fields)-2])
+	if err != nil {
+		return fmt.Errorf("invalid score: %w", err)
+	}
+	fields = fields[:len(fields)-2]
+
+	// time penalties e.g. ">alice: QZ (time) -10 80"
+	if fields[len(fields)-1] == "(time)" {
+		return g.record(ScoreAdjusted{Player: player.Name, Points: score, Reason: "time penalty"})
+	}
+
+	// end of game tiles e.g. ">alice: (QZ) +20 120" or ">alice: QZ (QZ) -20 80"
+	if last := fields[len(fields)-1]; strings.HasPrefix(last, "(") && last != "(challenge)" {
+		if !g.Complete {
+			return fmt.Errorf("end of game tiles recorded before the game ended")
+		}
+		if len(fields) > 1 && g.EndReason == EndScorelessTurns {
+			// the player's rack may not be known until now if their last turn was an exchange
+			before := rackValue(player.Letters)
+			if err := g.setRack(playerIdx, gcgRack(fields[0])); err != nil {
+				return err
+			}
+			if adjustment := before - rackValue(player.Letters); adjustment != 0 {
+				return g.record(ScoreAdjusted{Player: player.Name, Points: adjustment, Reason: "end of game tiles"})
+			}
+		}
+		return nil
+	}
+
+	// the rack is optional so a move is either RACK ACTION, RACK COORDINATE WORD, COORDINATE WORD or ACTION
+	var rack []rune
+	if len(fields) == 3 || (len(fields) == 2 && strings.ContainsAny(fields[1][:1], "-(")) {
+		rack = gcgRack(fields[0])
+		fields = fields[1:]
+	}
+
+	switch fields[0] {
+	case "--":
+		if err := g.record(PlayWithdrawn{Player: player.Name}); err != nil {
+			return err
+		}
+		return g.endTurn()
+	case "(challenge)":
+		if rack != nil {
+			if err := g.setRack(playerIdx, rack); err != nil {
+				return err
+			}
+		}
+		return g.record(ChallengeFailed{Challenged: player.Name, Bonus: score})
+	}
+
+	if g.Complete {
+		return fmt.Errorf("game is complete")
+	}
+	if rack != nil {
+		if err := g.setRack(playerIdx, rack); err != nil {
+			return err
+		}
+	}
+	if g.CurrentPlayer != playerIdx {
+		return fmt.Errorf("it is not %s's turn", player.Name)
+	}
+	switch {
+	case fields[0] == "-":
+		return g.Pass()
+	case strings.HasPrefix(fields[0], "-"):
+		exchanged := gcgRack(strings.TrimPrefix(fields[0], "-"))
+		if n, err := strconv.Atoi(string(exchanged)); err == nil {
+			// only the number of tiles was recorded
+			exchanged = slices.Clone(player.Letters[:min(n, len(player.Letters))])
+		}
+		return g.Exchange(exchanged)
+	}
+
+	if len(fields) != 2 {
+		return fmt.Errorf("invalid move: %s", line)
+	}
+	place, err := parseCoordinate(len(g.Board), fields[0])
+	if err != nil {
+		return err
+	}
+	word := []rune(fields[1])
+	for i, l := range word {
+		if l != '.' {
+			continue
+		}
+		cell := g.Board.GetCell(g.Board.getCellIndex(place, i), CellAny)
+		if cell == nil || cell.Empty() {
+			return fmt.Errorf("no tile to play through at position %d of %s", i+1, fields[1])
+		}
+		word[i] = cell.Char
+	}
+	if err := g.PlaceWord(place, string(word)); err != nil {
+		return err
+	}
+	if last := g.History[len(g.History)-1]; last.Score != score {
+		return fmt.Errorf("%s scored %d but the record says %d", string(word), last.Score, score)
+	}
+	return nil
+}
+
+// setRack gives a player the exact tiles recorded for them. Any tiles already on their rack go back to the bag
+// first and if a tile is held by another player it is swapped for one from the bag.
+func (g *Classic) setRack(playerIdx int, rack []rune) error {
+	player, err := g.getPlayer(playerIdx)
+	if err != nil {
+		return err
+	}
+	if len(player.Letters) > 0 {
+		if err := g.record(TilesReturned{Player: player.Name, Tiles: string(player.Letters)}); err != nil {
+			return err
+		}
+	}
+	for _, l := range rack {
+		var holder *Player
+		if !g.SpareLetters.contains([]rune{l}) {
+			for _, other := range g.Players {
+				if other != player && other.hasLetters([]rune{l}) {
+					holder = other
+					break
+				}
+			}
+			if holder == nil {
+				return fmt.Errorf("no %s tile left to give to %s", string(l), player.Name)
+			}
+			if err := g.record(TilesReturned{Player: holder.Name, Tiles: string(l)}); err != nil {
+				return err
+			}
+		}
+		if err := g.record(TilesDrawn{Player: player.Name, Tiles: string(l)}); err != nil {
+			return err
+		}
+		if holder != nil {
+			if err := g.record(TilesDrawn{Player: holder.Name, Tiles: string(g.SpareLetters.pick(1))}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// gcgTiles formats tiles with blanks written as '?'.
+func gcgTiles(tiles []rune) string {
+	return strings.ReplaceAll(string(tiles), string(blankLetter), "?")
+}
+
+func gcgRack(rack string) []rune {
+	return []rune(strings.ReplaceAll(strings.ToUpper(rack), "?", string(blankLetter)))
+}
+
+// gcgNickname removes whitespace from a player name as GCG nicknames must be a single word.
+func gcgNickname(name string) string {
+	return strings.Join(strings.Fields(name), "_")
+}
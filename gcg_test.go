@@ -0,0 +1,261 @@
+package scrabble
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func exportGCG(t *testing.T, g *Classic) string {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	if err := ExportGCG(g, buf); err != nil {
+		t.Fatalf("ExportGCG() error = %v", err)
+	}
+	return buf.String()
+}
+
+// newTestGCGGame plays moves, an exchange, passes, a failed and a successful challenge and a blank then ends the
+// game with six scoreless turns.
+func newTestGCGGame(t *testing.T) *Classic {
+	t.Helper()
+	game := NewClassicGame(WithSeed(1), WithLexicon(NewWordList("CAT", "CATS")), WithChallengeRule(ChallengeFivePoint))
+	for _, name := range []string{"alice", "bob smith"} {
+		if err := game.AddPlayer(name); err != nil {
+			t.Fatalf("AddPlayer() error = %v", err)
+		}
+	}
+	game.Players[0].Letters = []rune("CA_SQIE")
+
+	steps := []func() error{
+		func() error { return game.PlaceWord(Placement{CellId: 112, Direction: Across}, "CAt") },
+		func() error { return game.Exchange(game.Players[1].Letters[:2]) },
+		func() error { return game.PlaceWord(Placement{CellId: 112, Direction: Across}, "CATS") },
+		func() error {
+			_, err := game.Challenge()
+			return err
+		},
+		game.Pass,
+		func() error { return game.PlaceWord(Placement{CellId: 85, Direction: Down}, "QIS") },
+		func() error {
+			_, err := game.Challenge()
+			return err
+		},
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("step %d error = %v", i, err)
+		}
+	}
+	for !game.Complete {
+		if err := game.Pass(); err != nil {
+			t.Fatalf("Pass() error = %v", err)
+		}
+	}
+	return game
+}
+
+// newTestGCGOutGame is played without a lexicon until a player goes out. Each turn fills as much of a column as
+// the rack allows, starting from the word across the centre.
+func newTestGCGOutGame(t *testing.T) *Classic {
+	t.Helper()
+	game := newTestClassicGame(t, 2, "alice", "bob")
+	// any blank is played as an E
+	letter := func(tile rune) rune {
+		if tile == blankLetter {
+			return 'e'
+		}
+		return tile
+	}
+
+	first := []rune{}
+	for _, tile := range game.Players[0].Letters {
+		first = append(first, letter(tile))
+	}
+	if err := game.PlaceWord(Placement{CellId: 110, Direction: Across}, string(first)); err != nil {
+		t.Fatalf("PlaceWord() error = %v", err)
+	}
+	for col := 4; !game.Complete; {
+		if col > 10 {
+			t.Fatalf("ran out of columns")
+		}
+		top, bottom := -1, -1
+		for row := range StandardBoardSize {
+			if !game.Board[row][col].Empty() {
+				if top == -1 {
+					top = row
+				}
+				bottom = row
+			}
+		}
+		free := top + StandardBoardSize - 1 - bottom
+		if free == 0 {
+			col++
+			continue
+		}
+		player, _ := game.GetCurrentPlayer()
+		tiles := min(len(player.Letters), free)
+		up := min(tiles, top)
+		word, next := []rune{}, 0
+		for row := top - up; row <= bottom+tiles-up; row++ {
+			if cell := game.Board[row][col]; !cell.Empty() {
+				word = append(word, cell.Char)
+				continue
+			}
+			word = append(word, letter(player.Letters[next]))
+			next++
+		}
+		placement := Placement{CellId: int64((top-up)*StandardBoardSize + col + 1), Direction: Down}
+		if err := game.PlaceWord(placement, string(word)); err != nil {
+			t.Fatalf("PlaceWord(%s, %s) error = %v", placement, string(word), err)
+		}
+	}
+	if game.EndReason != EndPlayerWentOut {
+		t.Fatalf("game ended with %s, want a player to go out", game.EndReason)
+	}
+	return game
+}
+
+// sortEndTiles sorts the tiles listed at the end of the game. The last tiles drawn are not recorded so an imported game
+// may have them in a different order.
+func sortEndTiles(gcg string) string {
+	lines := strings.Split(gcg, "\n")
+	for i, line := range lines {
+		start, end := strings.LastIndex(line, "("), strings.LastIndex(line, ")")
+		if start == -1 || end < start || line[start:end+1] == "(challenge)" {
+			continue
+		}
+		lines[i] = line[:start+1] + sortedLetters([]rune(line[start+1:end])) + line[end:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestExportGCG(t *testing.T) {
+	want := `#character-encoding UTF-8
+#player1 alice alice
+#player2 bob_smith bob smith
+>alice: CA?SQIE 8G CAt +8 8
+>bob_smith: BNFIZHI -BN +0 0
+>alice: SQIEATD 8G ...S +5 13
+>alice: QIEATDE (challenge) +5 18
+>bob_smith: FIZHIDI - +0 0
+>alice: QIEATDE J6 QI. +32 50
+>alice: QIEATDE -- -32 18
+>bob_smith: FIZHIDI - +0 0
+>alice: QIEATDE - +0 18
+>bob_smith: FIZHIDI - +0 0
+>alice: QIEATDE - +0 18
+>alice: QIEATDE (QIEATDE) -17 1
+>bob_smith: FIZHIDI (FIZHIDI) -23 -23
+`
+	if got := exportGCG(t, newTestGCGGame(t)); got != want {
+		t.Errorf("ExportGCG() = \n%s\nwant\n%s", got, want)
+	}
+}
+
+// newTestGCGNicknameGame has players whose names would all be written as the same nickname.
+func newTestGCGNicknameGame(t *testing.T) *Classic {
+	t.Helper()
+	game := NewClassicGame(WithSeed(1))
+	for _, name := range []string{"a b", "a_b", "a_b_2"} {
+		if err := game.AddPlayer(name); err != nil {
+			t.Fatalf("AddPlayer() error = %v", err)
+		}
+	}
+	for !game.Complete {
+		if err := game.Pass(); err != nil {
+			t.Fatalf("Pass() error = %v", err)
+		}
+	}
+	return game
+}
+
+func TestImportGCG_roundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		game func(t *testing.T) *Classic
+	}{
+		{name: "challenges and scoreless end", game: newTestGCGGame},
+		{name: "player goes out", game: newTestGCGOutGame},
+		{name: "colliding nicknames", game: newTestGCGNicknameGame},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := tt.game(t)
+			exported := exportGCG(t, game)
+
+			imported, err := ImportGCG(strings.NewReader(exported))
+			if err != nil {
+				t.Fatalf("ImportGCG() error = %v", err)
+			}
+			if got := exportGCG(t, imported); sortEndTiles(got) != sortEndTiles(exported) {
+				t.Errorf("re-exported GCG = \n%s\nwant\n%s", got, exported)
+			}
+			if !reflect.DeepEqual(imported.Board, game.Board) {
+				t.Errorf("imported board differs")
+			}
+			for i, p := range game.Players {
+				got := imported.Players[i]
+				if got.Name != p.Name || got.Score != p.Score || sortedLetters(got.Letters) != sortedLetters(p.Letters) {
+					t.Errorf("imported player %s with %d and rack %s, want %s with %d and rack %s", got.Name, got.Score, string(got.Letters), p.Name, p.Score, string(p.Letters))
+				}
+			}
+			if len(imported.History) != len(game.History) {
+				t.Fatalf("imported %d moves, want %d", len(imported.History), len(game.History))
+			}
+			for i, m := range game.History {
+				got := imported.History[i]
+				if got.Player != m.Player || got.Type != m.Type || got.Word != m.Word || got.Score != m.Score {
+					t.Errorf("move %d = %s %s %s %d, want %s %s %s %d", i, got.Player, got.Type, got.Word, got.Score, m.Player, m.Type, m.Word, m.Score)
+				}
+			}
+			want, err := game.Result()
+			if err != nil {
+				t.Fatalf("Result() error = %v", err)
+			}
+			got, err := imported.Result()
+			if err != nil {
+				t.Fatalf("imported Result() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("imported result = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestImportGCG_invalid(t *testing.T) {
+	players := "#player1 alice Alice\n#player2 bob Bob\n"
+	tests := []struct {
+		name string
+		gcg  string
+	}{
+		{name: "player without nickname", gcg: "#player1\n"},
+		{name: "duplicate player", gcg: "#player1 alice Alice\n#player2 alice Alice\n"},
+		{name: "unknown player", gcg: players + ">carol: CAT 8G CAT +10 10\n"},
+		{name: "missing colon", gcg: players + ">alice CAT 8G CAT +10 10\n"},
+		{name: "too few fields", gcg: players + ">alice: +10 10\n"},
+		{name: "invalid score", gcg: players + ">alice: CAT 8G CAT +ten 10\n"},
+		{name: "invalid coordinate", gcg: players + ">alice: CAT 8Z CAT +10 10\n"},
+		{name: "off the board", gcg: players + ">alice: CAT 16A CAT +10 10\n"},
+		{name: "wrong score", gcg: players + ">alice: CAT 8G CAT +11 11\n"},
+		{name: "not on the rack", gcg: players + ">alice: CAT 8G DOG +10 10\n"},
+		{name: "tiles not left", gcg: players + ">alice: ZZZ 8G ZZZ +60 60\n"},
+		{name: "out of turn", gcg: players + ">bob: CAT 8G CAT +10 10\n"},
+		{name: "first word off centre", gcg: players + ">alice: CAT 1A CAT +5 5\n"},
+		{name: "play through nothing", gcg: players + ">alice: CAT 8G C.T +10 10\n"},
+		{name: "too many fields", gcg: players + ">alice: CAT 8G CAT EXTRA +10 10\n"},
+		{name: "withdraw nothing", gcg: players + ">alice: CAT -- -10 0\n"},
+		{name: "exchange tiles not on rack", gcg: players + ">alice: ABCDEFG -XYZ +0 0\n"},
+		{name: "end tiles before the end", gcg: players + ">alice: (QZ) +20 20\n"},
+		{name: "move after the end", gcg: players + strings.Repeat(">alice: - +0 0\n>bob: - +0 0\n", 3) + ">alice: - +0 0\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ImportGCG(strings.NewReader(tt.gcg)); err == nil {
+				t.Errorf("ImportGCG() expected error")
+			}
+		})
+	}
+}
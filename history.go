@@ -0,0 +1,49 @@
+package scrabble
+
+import "fmt"
+
+// Undo reverts the most recent turn, restoring the board, bag, racks and scores to exactly how they were before
+// it. Challenges are undone in the same way as turns. Bots do not take their turn again until PlayBotTurns is
+// called.
+func (g *Classic) Undo() error {
+	return g.record(TurnUndone{})
+}
+
+// Redo replays a turn reverted by Undo. Taking a new turn discards any turns that could have been redone.
+func (g *Classic) Redo() error {
+	return g.record(TurnRedone{})
+}
+
+func (g *Classic) CanUndo() bool {
+	return len(g.undoStates) > 0
+}
+
+func (g *Classic) CanRedo() bool {
+	return len(g.redoStates) > 0
+}
+
+func (g *Classic) undo() error {
+	if len(g.undoStates) == 0 {
+		return fmt.Errorf("there is nothing to undo")
+	}
+	g.redoStates = append(g.redoStates, g.snapshot())
+	g.restore(g.undoStates[len(g.undoStates)-1])
+	g.undoStates = g.undoStates[:len(g.undoStates)-1]
+	return nil
+}
+
+func (g *Classic) redo() error {
+	if len(g.redoStates) == 0 {
+		return fmt.Errorf("there is nothing to redo")
+	}
+	g.undoStates = append(g.undoStates, g.snapshot())
+	g.restore(g.redoStates[len(g.redoStates)-1])
+	g.redoStates = g.redoStates[:len(g.redoStates)-1]
+	return nil
+}
+
+// saveUndoState records the state at the start of a turn.
+func (g *Classic) saveUndoState(state classicState) {
+	g.undoStates = append(g.undoStates, state)
+	g.redoStates = nil
+}
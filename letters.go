@@ -1,5 +1,13 @@
 package scrabble
 
+import (
+	"maps"
+	"slices"
+)
+
+// blankLetter represents a blank tile in a rack or the bag.
+const blankLetter rune = '_'
+
 var LetterScores = map[rune]int{
 	'A': 1,
 	'B': 3,
@@ -71,6 +79,9 @@ var StandardBonusMap = map[int]CellBonusType{
 	218: TripleWordScoreType,
 	225: TripleWordScoreType,
 
+	// Centre star
+	113: DoubleWordScoreType,
+
 	// Triple Letter Scores
 	21:  TripleLetterScoreType,
 	25:  TripleLetterScoreType,
@@ -130,10 +141,27 @@ var StandardBonusMap = map[int]CellBonusType{
 	173: DoubleLetterScoreType,
 }
 
+// takeLetters removes the letters from the rack, returning the remaining letters in their original order.
+// Blanks must be requested explicitly with '_', they are never substituted for other letters.
+func takeLetters(rack []rune, letters []rune) ([]rune, bool) {
+	remaining := slices.Clone(rack)
+	foundAll := true
+	for _, want := range letters {
+		idx := slices.Index(remaining, want)
+		if idx == -1 {
+			foundAll = false
+			continue
+		}
+		remaining = slices.Delete(remaining, idx, idx+1)
+	}
+	return remaining, foundAll
+}
+
 func makeLetterBag() []rune {
 	bag := []rune{}
-	for letter, count := range LetterDistribution {
-		bag = append(bag, repeatLetter(letter, count)...)
+	// the bag must always be in the same order for seeded draws to be repeatable
+	for _, letter := range slices.Sorted(maps.Keys(LetterDistribution)) {
+		bag = append(bag, repeatLetter(letter, LetterDistribution[letter])...)
 	}
 	return bag
 }
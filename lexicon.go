@@ -0,0 +1,41 @@
+package scrabble
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lexicon is a dictionary used to decide if a word formed on the board is valid.
+type Lexicon interface {
+	Contains(word string) bool
+}
+
+// WordList is a simple in-memory Lexicon. Words are stored upper case.
+type WordList map[string]struct{}
+
+func NewWordList(words ...string) WordList {
+	list := make(WordList, len(words))
+	for _, v := range words {
+		list[strings.ToUpper(strings.TrimSpace(v))] = struct{}{}
+	}
+	return list
+}
+
+func (l WordList) Contains(word string) bool {
+	_, ok := l[strings.ToUpper(word)]
+	return ok
+}
+
+// validateWords checks the main word and all cross-words of a placement against the lexicon.
+// A nil lexicon allows any word.
+func validateWords(lexicon Lexicon, result *PlacementResult) error {
+	if lexicon == nil {
+		return nil
+	}
+	for _, word := range result.Words() {
+		if !lexicon.Contains(word) {
+			return fmt.Errorf("%s is not a valid word", word)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package scrabble
+
+import (
+	"testing"
+)
+
+func TestClassic_PlaceWord_lexicon(t *testing.T) {
+	tests := []struct {
+		name    string
+		words   []string
+		wantErr string
+	}{
+		{
+			name:  "main and cross words are valid",
+			words: []string{"CAT", "SO", "CATS"},
+		},
+		{
+			name:    "invalid main word",
+			words:   []string{"CAT", "CATS"},
+			wantErr: "SO is not a valid word",
+		},
+		{
+			name:    "invalid cross word",
+			words:   []string{"CAT", "SO"},
+			wantErr: "CATS is not a valid word",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewClassicGame(WithLexicon(NewWordList(tt.words...)))
+			for _, name := range []string{"alice", "bob"} {
+				if err := game.AddPlayer(name); err != nil {
+					t.Fatalf("AddPlayer() error = %v", err)
+				}
+			}
+			game.Players[0].Letters = []rune("CATXYZQ")
+			if err := game.PlaceWord(Placement{CellId: 112, Direction: Across}, "CAT"); err != nil {
+				t.Fatalf("PlaceWord() error = %v", err)
+			}
+
+			// S hooks onto CAT making the cross word CATS
+			game.Players[1].Letters = []rune("SOXYZQE")
+			err := game.PlaceWord(Placement{CellId: 115, Direction: Down}, "SO")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("PlaceWord() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("PlaceWord() error = %v, want %s", err, tt.wantErr)
+			}
+			if game.Board.GetCell(115, CellFull) != nil {
+				t.Errorf("rejected word was placed on the board")
+			}
+			if got := string(game.Players[1].Letters); got != "SOXYZQE" {
+				t.Errorf("rack = %s after a rejected word, want SOXYZQE", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package scrabble
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	rackBlankIdx   = 26
+	allLettersMask = uint32(1<<26 - 1)
+)
+
+// Play is a legal placement found by GeneratePlays.
+type Play struct {
+	Placement Placement
+	Word      string
+	Result    *PlacementResult
+	Score     int
+}
+
+func (p *Play) String() string {
+	return fmt.Sprintf("%s %s (%d)", p.Placement.String(), p.Word, p.Score)
+}
+
+// GeneratePlays returns every legal play that can be made on the board with the given rack, highest scoring first.
+// The rack may contain blanks (_) which are returned as lower case letters in the play's word. Plays are checked
+// with the same rules used when placing a word so any of them can be passed directly to Classic.PlaceWord.
+func GeneratePlays(board Board, rack []rune, gaddag *GADDAG) []*Play {
+	gen := &playGenerator{
+		board:     board,
+		gaddag:    gaddag.graph,
+		size:      len(board),
+		firstWord: boardEmpty(board),
+		seen:      map[string]struct{}{},
+		plays:     make([]*Play, 0),
+	}
+	for _, r := range rack {
+		if r == blankLetter {
+			gen.rack[rackBlankIdx]++
+			continue
+		}
+		if r = unicode.ToUpper(r); r >= 'A' && r <= 'Z' {
+			gen.rack[r-'A']++
+		}
+	}
+
+	for _, orientation := range []Orientation{Across, Down} {
+		gen.orientation = orientation
+		for line := range gen.size {
+			gen.line = line
+			gen.prepareLine()
+			for pos := range gen.size {
+				if gen.anchors[pos] {
+					gen.anchor = pos
+					gen.gen(pos, 1)
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(gen.plays, func(a, b *Play) int {
+		if a.Score != b.Score {
+			return b.Score - a.Score
+		}
+		if c := cmp.Compare(a.Placement.String(), b.Placement.String()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Word, b.Word)
+	})
+	return gen.plays
+}
+
+func boardEmpty(board Board) bool {
+	for _, row := range board {
+		for _, cell := range row {
+			if !cell.Empty() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type genTile struct {
+	letter byte
+	blank  bool
+	placed bool
+}
+
+// playGenerator walks each row and column of the board growing words outwards from anchor squares.
+type playGenerator struct {
+	board     Board
+	gaddag    *graph
+	size      int
+	firstWord bool
+	rack      [27]int
+	seen      map[string]struct{}
+	plays     []*Play
+
+	// state for the line currently being searched
+	orientation Orientation
+	line        int
+	anchor      int
+	anchors     []bool
+	crossChecks []uint32
+	left        []genTile // tiles from the anchor leftwards (i.e. reversed)
+	right       []genTile // tiles to the right of the anchor
+}
+
+// coords converts a position in the current line to a row and column.
+func (g *playGenerator) coords(pos int) (int, int) {
+	if g.orientation == Across {
+		return g.line, pos
+	}
+	return pos, g.line
+}
+
+func (g *playGenerator) letterAt(row, col int) (byte, bool) {
+	if row < 0 || col < 0 || row >= g.size || col >= g.size {
+		return 0, false
+	}
+	cell := g.board[row][col]
+	if cell.Empty() {
+		return 0, false
+	}
+	return byte(unicode.ToUpper(cell.Char) - 'A'), true
+}
+
+func (g *playGenerator) lineLetter(pos int) (byte, bool) {
+	return g.letterAt(g.coords(pos))
+}
+
+func (g *playGenerator) filled(pos int) bool {
+	_, ok := g.lineLetter(pos)
+	return ok
+}
+
+func (g *playGenerator) inLine(pos int) bool {
+	return pos >= 0 && pos < g.size
+}
+
+func (g *playGenerator) prepareLine() {
+	g.anchors = make([]bool, g.size)
+	g.crossChecks = make([]uint32, g.size)
+
+	for pos := range g.size {
+		row, col := g.coords(pos)
+		if _, ok := g.letterAt(row, col); ok {
+			continue
+		}
+		if g.firstWord {
+			g.anchors[pos] = row == g.size/2 && col == g.size/2
+		} else {
+			for _, offset := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+				if _, ok := g.letterAt(row+offset[0], col+offset[1]); ok {
+					g.anchors[pos] = true
+				}
+			}
+		}
+		g.crossChecks[pos] = g.crossCheck(row, col)
+	}
+}
+
+// crossCheck returns a mask of the letters that form a valid word with any tiles directly above and below
+// (or left and right for down words) of the given square.
+func (g *playGenerator) crossCheck(row, col int) uint32 {
+	dRow, dCol := 1, 0
+	if g.orientation == Down {
+		dRow, dCol = 0, 1
+	}
+	prefix := []byte{}
+	for r, c := row-dRow, col-dCol; ; r, c = r-dRow, c-dCol {
+		letter, ok := g.letterAt(r, c)
+		if !ok {
+			break
+		}
+		prefix = append(prefix, letter)
+	}
+	slices.Reverse(prefix)
+	suffix := []byte{}
+	for r, c := row+dRow, col+dCol; ; r, c = r+dRow, c+dCol {
+		letter, ok := g.letterAt(r, c)
+		if !ok {
+			break
+		}
+		suffix = append(suffix, letter)
+	}
+	if len(prefix) == 0 && len(suffix) == 0 {
+		return allLettersMask
+	}
+
+	mask := uint32(0)
+	word := make([]byte, len(prefix)+1+len(suffix))
+	copy(word, prefix)
+	copy(word[len(prefix)+1:], suffix)
+	for l := range byte(26) {
+		word[len(prefix)] = l
+		reversed := slices.Clone(word)
+		slices.Reverse(reversed)
+		if g.gaddag.containsSymbols(reversed) {
+			mask |= 1 << l
+		}
+	}
+	return mask
+}
+
+func (g *playGenerator) gen(pos int, node uint32) {
+	if letter, ok := g.lineLetter(pos); ok {
+		g.goOn(pos, genTile{letter: letter}, node)
+		return
+	}
+	checks := g.crossChecks[pos]
+	for l := range byte(26) {
+		if checks&(1<<l) == 0 {
+			continue
+		}
+		if g.rack[l] > 0 {
+			g.rack[l]--
+			g.goOn(pos, genTile{letter: l, placed: true}, node)
+			g.rack[l]++
+		}
+		if g.rack[rackBlankIdx] > 0 {
+			g.rack[rackBlankIdx]--
+			g.goOn(pos, genTile{letter: l, placed: true, blank: true}, node)
+			g.rack[rackBlankIdx]++
+		}
+	}
+}
+
+func (g *playGenerator) goOn(pos int, tile genTile, node uint32) {
+	edge := g.gaddag.child(node, tile.letter)
+	if edge == 0 {
+		return
+	}
+	terminal := g.gaddag.edges[edge]&edgeTerminal != 0
+	next := g.gaddag.edges[edge] >> edgeChildShift
+
+	if pos <= g.anchor {
+		// still building the prefix leftwards from the anchor
+		g.left = append(g.left, tile)
+		if terminal && !g.filled(pos-1) && !g.filled(g.anchor+1) {
+			g.record(pos)
+		}
+		if next != 0 {
+			// squares left of the anchor may only be filled if they are not also anchors, otherwise the same
+			// word would be found again from the other anchor.
+			if g.inLine(pos-1) && (g.filled(pos-1) || !g.anchors[pos-1]) {
+				g.gen(pos-1, next)
+			}
+			if sep := g.gaddag.child(next, gaddagSeparator); sep != 0 && !g.filled(pos-1) && g.inLine(g.anchor+1) {
+				g.gen(g.anchor+1, g.gaddag.edges[sep]>>edgeChildShift)
+			}
+		}
+		g.left = g.left[:len(g.left)-1]
+		return
+	}
+
+	g.right = append(g.right, tile)
+	if terminal && !g.filled(pos+1) {
+		g.record(g.anchor - len(g.left) + 1)
+	}
+	if next != 0 && g.inLine(pos+1) {
+		g.gen(pos+1, next)
+	}
+	g.right = g.right[:len(g.right)-1]
+}
+
+func (g *playGenerator) record(start int) {
+	tiles := slices.Clone(g.left)
+	slices.Reverse(tiles)
+	tiles = append(tiles, g.right...)
+
+	word := strings.Builder{}
+	key := strings.Builder{}
+	for i, t := range tiles {
+		if t.blank {
+			word.WriteRune(rune('a' + t.letter))
+		} else {
+			word.WriteRune(rune('A' + t.letter))
+		}
+		if t.placed {
+			row, col := g.coords(start + i)
+			fmt.Fprintf(&key, "%d:%d:%d:%t,", row, col, t.letter, t.blank)
+		}
+	}
+
+	// a play that adds a single tile may be found as both an across and a down word
+	if _, ok := g.seen[key.String()]; ok {
+		return
+	}
+	g.seen[key.String()] = struct{}{}
+
+	row, col := g.coords(start)
+	placement := Placement{CellId: int64(row*g.size + col + 1), Direction: g.orientation}
+	result, err := g.board.isValidWordPlacement(placement, word.String(), g.firstWord)
+	if err != nil {
+		return
+	}
+	g.plays = append(g.plays, &Play{
+		Placement: placement,
+		Word:      word.String(),
+		Result:    result,
+		Score:     result.Score(),
+	})
+}
@@ -0,0 +1,229 @@
+package scrabble
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+var testMoveGenWords = []string{
+	"AS", "AT", "AX", "ACT", "ACTS", "CAT", "CATS", "IS", "IT", "ITS", "SAT", "SCAT", "SIT", "TA", "TAX", "TI",
+	"TIS", "XI", "AXIS",
+}
+
+// playKey identifies a play by the tiles it places, blanks as lower case.
+func playKey(t *testing.T, board Board, placement Placement, word string) string {
+	t.Helper()
+	tiles := []string{}
+	for i, letter := range word {
+		cell := board.GetCell(board.getCellIndex(placement, i), CellAny)
+		if cell == nil {
+			t.Fatalf("%s %s does not fit on the board", placement, word)
+		}
+		if cell.Empty() {
+			tiles = append(tiles, fmt.Sprintf("%d%c", cell.Index, letter))
+		}
+	}
+	slices.Sort(tiles)
+	return strings.Join(tiles, ",")
+}
+
+// bruteForcePlays tries every word in the lexicon at every cell in both directions, with each letter either upper or
+// lower case, and returns the score of each legal play keyed by the tiles it places. Case is ignored for letters that
+// are already on the board so those variants are the same play.
+func bruteForcePlays(t *testing.T, board Board, rack []rune, words []string) map[string]int {
+	t.Helper()
+	lexicon := NewWordList(words...)
+	rackCount := map[rune]int{}
+	for _, r := range rack {
+		rackCount[r]++
+	}
+	plays := map[string]int{}
+	for cellID := int64(1); cellID <= int64(len(board)*len(board)); cellID++ {
+		for _, dir := range []Orientation{Across, Down} {
+			placement := Placement{CellId: cellID, Direction: dir}
+			for _, word := range words {
+				for blanks := range 1 << len(word) {
+					candidate := []rune(word)
+					for i := range candidate {
+						if blanks&(1<<i) != 0 {
+							candidate[i] = unicode.ToLower(candidate[i])
+						}
+					}
+					result, err := board.isValidWordPlacement(placement, string(candidate), boardEmpty(board))
+					if err != nil {
+						continue
+					}
+					if validateWords(lexicon, result) != nil {
+						continue
+					}
+					spent := map[rune]int{}
+					for _, r := range result.LettersSpent {
+						spent[r]++
+					}
+					fits := true
+					for r, n := range spent {
+						fits = fits && rackCount[r] >= n
+					}
+					if !fits {
+						continue
+					}
+					plays[playKey(t, board, placement, string(candidate))] = result.Score()
+				}
+			}
+		}
+	}
+	return plays
+}
+
+func TestGeneratePlays(t *testing.T) {
+	gaddag, err := NewGADDAG(testMoveGenWords)
+	if err != nil {
+		t.Fatalf("NewGADDAG() error = %v", err)
+	}
+	tests := []struct {
+		name  string
+		board Board
+		rack  string
+	}{
+		{
+			name:  "empty board",
+			board: NewBoard(7),
+			rack:  "CATSIX",
+		},
+		{
+			name:  "empty board with blank",
+			board: NewBoard(7),
+			rack:  "C_T",
+		},
+		{
+			name:  "hooks",
+			board: NewBoard(7, InitialWord{Placement: Placement{CellId: 24, Direction: Across}, Word: "CAT"}),
+			rack:  "SIAX",
+		},
+		{
+			name:  "hooks with blanks",
+			board: NewBoard(7, InitialWord{Placement: Placement{CellId: 24, Direction: Across}, Word: "CAT"}),
+			rack:  "_S_",
+		},
+		{
+			name: "edge of board",
+			board: NewBoard(
+				7,
+				InitialWord{Placement: Placement{CellId: 5, Direction: Across}, Word: "CAT"},
+				InitialWord{Placement: Placement{CellId: 7, Direction: Down}, Word: "TAX"},
+				InitialWord{Placement: Placement{CellId: 43, Direction: Across}, Word: "ITS"},
+			),
+			rack: "ASIT_",
+		},
+		{
+			name:  "existing blank",
+			board: NewBoard(7, InitialWord{Placement: Placement{CellId: 25, Direction: Down}, Word: "aT"}),
+			rack:  "CSX",
+		},
+		{
+			name:  "no plays",
+			board: NewBoard(7, InitialWord{Placement: Placement{CellId: 25, Direction: Down}, Word: "AT"}),
+			rack:  "QQ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plays := GeneratePlays(tt.board, []rune(tt.rack), gaddag)
+
+			got := map[string]int{}
+			for i, p := range plays {
+				key := playKey(t, tt.board, p.Placement, p.Word)
+				if _, ok := got[key]; ok {
+					t.Errorf("play %s places the same tiles as an earlier play", p)
+				}
+				got[key] = p.Score
+				if p.Score != p.Result.Score() {
+					t.Errorf("play %s score = %d, result scores %d", p, p.Score, p.Result.Score())
+				}
+				if i > 0 && plays[i-1].Score < p.Score {
+					t.Errorf("play %s is after lower scoring play %s", p, plays[i-1])
+				}
+			}
+			want := bruteForcePlays(t, tt.board, []rune(tt.rack), testMoveGenWords)
+			for _, key := range slices.Sorted(maps.Keys(want)) {
+				if score, ok := got[key]; !ok {
+					t.Errorf("missing play %s (%d)", key, want[key])
+				} else if score != want[key] {
+					t.Errorf("play %s score = %d, want %d", key, score, want[key])
+				}
+			}
+			for _, key := range slices.Sorted(maps.Keys(got)) {
+				if _, ok := want[key]; !ok {
+					t.Errorf("unexpected play %s (%d)", key, got[key])
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratePlays_centre(t *testing.T) {
+	gaddag, err := NewGADDAG([]string{"CAT"})
+	if err != nil {
+		t.Fatalf("NewGADDAG() error = %v", err)
+	}
+	board := NewBoard(StandardBoardSize)
+	plays := GeneratePlays(board, []rune("CAT"), gaddag)
+	if len(plays) != 6 {
+		t.Fatalf("got %d plays, want 6: %v", len(plays), plays)
+	}
+	centre := board.getCenterCellIdx()
+	for _, p := range plays {
+		covered := false
+		for _, cell := range p.Result.Cells {
+			covered = covered || int64(cell.Index) == centre
+		}
+		if !covered {
+			t.Errorf("play %s does not cover the centre", p)
+		}
+	}
+}
+
+func TestGeneratePlays_dedupe(t *testing.T) {
+	t.Run("blank and letter plays are distinct", func(t *testing.T) {
+		gaddag, err := NewGADDAG([]string{"AT"})
+		if err != nil {
+			t.Fatalf("NewGADDAG() error = %v", err)
+		}
+		// AT or At or aT, starting on or before the centre, across or down
+		plays := GeneratePlays(NewBoard(7), []rune("AT_"), gaddag)
+		words := map[string]int{}
+		for _, p := range plays {
+			words[p.Word]++
+		}
+		if len(plays) != 12 || words["AT"] != 4 || words["aT"] != 4 || words["At"] != 4 {
+			t.Errorf("got plays %v, want 4 each of AT, aT and At", plays)
+		}
+	})
+	t.Run("single tile forming two words is found once", func(t *testing.T) {
+		gaddag, err := NewGADDAG([]string{"AT", "TA"})
+		if err != nil {
+			t.Fatalf("NewGADDAG() error = %v", err)
+		}
+		board := NewBoard(
+			7,
+			InitialWord{Placement: Placement{CellId: 25, Direction: Across}, Word: "AT"},
+			InitialWord{Placement: Placement{CellId: 25, Direction: Down}, Word: "AT"},
+		)
+		found := 0
+		for _, p := range GeneratePlays(board, []rune("A"), gaddag) {
+			if playKey(t, board, p.Placement, p.Word) == "33A" {
+				found++
+				if got := p.Result.Words(); len(got) != 2 || got[0] != "TA" || got[1] != "TA" {
+					t.Errorf("play %s forms %v, want [TA TA]", p, got)
+				}
+			}
+		}
+		if found != 1 {
+			t.Errorf("A at 33 found %d times, want 1", found)
+		}
+	})
+}
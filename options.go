@@ -0,0 +1,65 @@
+package scrabble
+
+import "math/rand/v2"
+
+type gameOpts struct {
+	lexicon       Lexicon
+	challengeRule ChallengeRule
+	randSource    rand.Source
+	clock         Clock
+	eventHandler  func(Event)
+}
+
+type GameOption func(opts *gameOpts)
+
+func resolveGameOptions(opts ...GameOption) *gameOpts {
+	opt := &gameOpts{challengeRule: ChallengeVoid, clock: systemClock{}}
+	for _, v := range opts {
+		v(opt)
+	}
+	return opt
+}
+
+// WithLexicon enables dictionary validation of placed words. Without a lexicon any word is accepted.
+func WithLexicon(lexicon Lexicon) GameOption {
+	return func(opts *gameOpts) {
+		opts.lexicon = lexicon
+	}
+}
+
+// WithChallengeRule sets how invalid words are handled in a Classic game. Any rule other than ChallengeVoid
+// allows invalid words to be played until they are challenged. Every play is rejected under an unknown rule so a
+// rule given by a user should be checked with ParseChallengeRule.
+func WithChallengeRule(rule ChallengeRule) GameOption {
+	return func(opts *gameOpts) {
+		opts.challengeRule = rule
+	}
+}
+
+// WithRandSource sets the source used to draw tiles from the bag.
+func WithRandSource(src rand.Source) GameOption {
+	return func(opts *gameOpts) {
+		opts.randSource = src
+	}
+}
+
+// WithSeed makes tile draws repeatable. Two games created with the same seed that are given the same moves will
+// draw the same tiles.
+func WithSeed(seed uint64) GameOption {
+	return WithRandSource(rand.NewPCG(seed, seed))
+}
+
+// WithClock replaces the system clock used to time the Scrabulous steal window e.g. with a FakeClock in tests.
+func WithClock(clock Clock) GameOption {
+	return func(opts *gameOpts) {
+		opts.clock = clock
+	}
+}
+
+// WithEventHandler is called with every event and notification from a ConcurrentScrabulous game once it has been
+// created. Events are delivered in order from a single goroutine so the handler may safely call back into the game.
+func WithEventHandler(handler func(Event)) GameOption {
+	return func(opts *gameOpts) {
+		opts.eventHandler = handler
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"golang.org/x/image/font/gofont/goregular"
 	"image/color"
 	"log"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 )
@@ -29,6 +31,8 @@ func resolveRenderOptions(opts ...RenderOption) *renderOpts {
 		cellBackgroundColor: color.RGBA{R: 225, G: 225, B: 211, A: 255},
 		wordColor:           color.Black,
 		labelColor:          color.RGBA{R: 200, G: 10, B: 10, A: 255},
+		blankColor:          color.RGBA{R: 120, G: 120, B: 120, A: 255},
+		highlightColor:      color.RGBA{R: 178, G: 222, B: 140, A: 255},
 		borderWidth:         20,
 	}
 	for _, v := range opts {
@@ -44,10 +48,17 @@ type renderOpts struct {
 	cellBackgroundColor color.Color
 	wordColor           color.Color
 	labelColor          color.Color
+	blankColor          color.Color
+	highlightColor      color.Color
+	tileTracker         bool
+	coordinateLabels    bool
 }
 
 type RenderOption func(opts *renderOpts)
 
+// minLabelBorderWidth is the smallest border that fits coordinate labels.
+const minLabelBorderWidth = 50
+
 func WithBorder(width int) RenderOption {
 	return func(opts *renderOpts) {
 		opts.borderWidth = width
@@ -84,357 +95,212 @@ func WithLabelColor(cl color.Color) RenderOption {
 	}
 }
 
-func RenderClassicPNG(c *Classic, width, height int, opts ...RenderOption) (*gg.Context, error) {
-	options := resolveRenderOptions(opts...)
-
-	gridWidth := height - options.borderWidth
-	gridHeight := height - options.borderWidth
-
-	cellWidth := float64(gridWidth / len(c.Board))
-	cellHeight := float64(gridHeight / len(c.Board))
-	cellOffset := 0.0
-	if options.borderWidth > 0 {
-		cellOffset = float64(options.borderWidth) / 2
+// WithBlankColor sets the colour of letters played using a blank tile.
+func WithBlankColor(cl color.Color) RenderOption {
+	return func(opts *renderOpts) {
+		opts.blankColor = cl
 	}
+}
 
-	dc := gg.NewContext(width, height)
-	dc.SetColor(options.backgroundColor)
-	dc.Clear()
-
-	// board
-
-	for gridY := 0; gridY < len(c.Board); gridY++ {
-		for gridX, cell := range c.Board[gridY] {
-
-			// draw cell with border
-			var cellColor color.Color = options.cellBackgroundColor
-			if cell.Bonus != NoBonusType {
-				switch cell.Bonus {
-				case DoubleLetterScoreType:
-					cellColor = color.RGBA{R: 183, G: 215, B: 230, A: 255}
-				case DoubleWordScoreType:
-					cellColor = color.RGBA{R: 216, G: 143, B: 139, A: 255}
-				case TripleLetterScoreType:
-					cellColor = color.RGBA{R: 84, G: 164, B: 198, A: 255}
-				case TripleWordScoreType:
-					cellColor = color.RGBA{R: 208, G: 44, B: 32, A: 255}
-				}
-			}
-			dc.SetColor(cellColor)
-			dc.DrawRectangle(cellOffset+(float64(gridX)*cellWidth), cellOffset+(float64(gridY)*cellHeight), cellWidth, cellHeight)
-			dc.FillPreserve()
-
-			dc.SetColor(options.wordColor)
-			dc.SetLineWidth(0.3)
-			dc.Stroke()
-
-			if !cell.Empty() {
-				dc.DrawRectangle(cellOffset+(float64(gridX)*cellWidth), cellOffset+(float64(gridY)*cellHeight), cellWidth, cellHeight)
-				dc.SetColor(options.wordBackgroundColor)
-				dc.FillPreserve()
-
-				// draw the word
-				dc.SetColor(options.wordColor)
-				dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 24}))
-				dc.DrawStringAnchored(
-					strings.ToUpper(cell.String()),
-					cellOffset+float64(gridX)*cellWidth+cellWidth/2,
-					cellOffset+float64(gridY)*cellHeight+cellHeight/2,
-					0.5,
-					0.5,
-				)
-
-				// draw letter score
-				dc.SetColor(options.wordColor)
-				dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 12}))
-				dc.DrawStringAnchored(
-					cell.LetterScoreString(),
-					cellOffset+float64(gridX)*cellWidth+cellWidth-12,
-					cellOffset+float64(gridY)*cellHeight+cellHeight-12,
-					0.5,
-					0.5,
-				)
-
-				dc.Stroke()
-			}
-
-			// draw cell index
-			dc.SetColor(color.RGBA{107, 107, 99, 255})
-			dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 14}))
-			dc.DrawStringAnchored(
-				cell.IndexString(),
-				cellOffset+float64(gridX)*cellWidth+12,
-				cellOffset+float64(gridY)*cellHeight+12,
-				0.5,
-				0.5,
-			)
-
-		}
+// WithHighlightColor sets the background colour of highlighted tiles e.g. the tiles placed by the last move.
+func WithHighlightColor(cl color.Color) RenderOption {
+	return func(opts *renderOpts) {
+		opts.highlightColor = cl
 	}
+}
 
-	// game information
-	dc.SetColor(color.Black)
-	dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 20}))
-	dc.DrawString(
-		"LEGEND",
-		float64(gridWidth)+float64(options.borderWidth),
-		20+float64(options.borderWidth)/2,
-	)
-	dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 18}))
-
-	dc.SetColor(color.RGBA{R: 208, G: 44, B: 32, A: 255})
-	dc.DrawString(
-		"Triple Word Score",
-		float64(gridWidth)+float64(options.borderWidth),
-		50+float64(options.borderWidth)/2,
-	)
-	dc.SetColor(color.RGBA{R: 216, G: 143, B: 139, A: 255})
-	dc.DrawString(
-		"Double Word Score",
-		float64(gridWidth)+float64(options.borderWidth),
-		70+float64(options.borderWidth)/2,
-	)
-	dc.SetColor(color.RGBA{R: 84, G: 164, B: 198, A: 255})
-	dc.DrawString(
-		"Triple Letter Score",
-		float64(gridWidth)+float64(options.borderWidth),
-		90+float64(options.borderWidth)/2,
-	)
-	dc.SetColor(color.RGBA{R: 183, G: 215, B: 230, A: 255})
-	dc.DrawString(
-		"Double Letter Score",
-		float64(gridWidth)+float64(options.borderWidth),
-		110+float64(options.borderWidth)/2,
-	)
-
-	dc.SetColor(color.Black)
-	dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 20}))
-	dc.DrawString(
-		fmt.Sprintf("TILES LEFT: %d", len(c.SpareLetters)),
-		float64(gridWidth)+float64(options.borderWidth),
-		150+float64(options.borderWidth)/2,
-	)
-
-	//scores
-	dc.SetColor(color.Black)
-	dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 20}))
-	dc.DrawString(
-		"PLAYER SCORES",
-		float64(gridWidth)+float64(options.borderWidth),
-		180+float64(options.borderWidth)/2,
-	)
+// WithTileTracker adds a panel below the scores listing the tiles the current player has not yet seen.
+func WithTileTracker() RenderOption {
+	return func(opts *renderOpts) {
+		opts.tileTracker = true
+	}
+}
 
-	dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 18}))
-	for i, p := range c.Players {
-		suffix := ""
-		if c.getCurrentPlayerName() == p.Name {
-			dc.SetColor(colornames.Darkblue)
-			suffix = " [current player]"
-		} else {
-			dc.SetColor(colornames.Black)
-		}
-		dc.DrawString(
-			fmt.Sprintf("%s: %d%s", p.Name, p.Score, suffix),
-			float64(gridWidth)+float64(options.borderWidth),
-			190+float64(options.borderWidth)/2+(25*float64(i+1)),
-		)
+// WithCoordinateLabels labels the rows with numbers and columns with letters instead of labelling every cell with
+// its index. The labels are drawn in the border so it is widened if necessary.
+func WithCoordinateLabels() RenderOption {
+	return func(opts *renderOpts) {
+		opts.coordinateLabels = true
+		opts.borderWidth = max(opts.borderWidth, minLabelBorderWidth)
 	}
+}
 
-	return dc, nil
+// Renderer is an output format that layers are drawn onto. Coordinates and font sizes are in pixels of the
+// rendered image.
+type Renderer interface {
+	Size() (width int, height int)
+	// Clear fills the whole image with a colour.
+	Clear(cl color.Color)
+	// Rect draws a rectangle filled with fill and outlined with stroke, either colour may be nil to skip it.
+	Rect(x, y, w, h float64, fill color.Color, stroke color.Color, strokeWidth float64)
+	// Text draws a string with its baseline starting at x,y.
+	Text(s string, x, y, size float64, cl color.Color)
+	// TextCentred draws a string centred on x,y.
+	TextCentred(s string, x, y, size float64, cl color.Color)
 }
 
-func RenderScrabulousPNG(c *Scrabulous, width, height int, opts ...RenderOption) (*gg.Context, error) {
-	options := resolveRenderOptions(opts...)
+// Layer is one part of a rendered game e.g. the board or a panel beside it.
+type Layer interface {
+	Draw(r Renderer, l *Layout) error
+}
 
-	gridWidth := height - options.borderWidth
-	gridHeight := height - options.borderWidth
+// Layout gives the position of the board and the panel beside it for an image size and set of options.
+type Layout struct {
+	Width  int
+	Height int
+	// CellOffset is the distance from the top left of the image to the board.
+	CellOffset float64
+	CellWidth  float64
+	CellHeight float64
+	// GridHeight is the space given to the board, it may be slightly larger than the board itself.
+	GridHeight float64
+	// PanelX is the left edge of the panel beside the board and PanelY is the top edge.
+	PanelX float64
+	PanelY float64
+
+	options *renderOpts
+}
 
-	cellWidth := float64(gridWidth / len(c.Board))
-	cellHeight := float64(gridHeight / len(c.Board))
-	cellOffset := 0.0
+// NewLayout fits a board with the given number of rows to the left of an image, leaving the rest for panels.
+func NewLayout(width, height, boardSize int, opts ...RenderOption) *Layout {
+	options := resolveRenderOptions(opts...)
+	gridSize := height - options.borderWidth
+	l := &Layout{
+		Width:      width,
+		Height:     height,
+		CellWidth:  float64(gridSize / boardSize),
+		CellHeight: float64(gridSize / boardSize),
+		GridHeight: float64(gridSize),
+		PanelX:     float64(gridSize + options.borderWidth),
+		PanelY:     float64(options.borderWidth) / 2,
+		options:    options,
+	}
 	if options.borderWidth > 0 {
-		cellOffset = float64(options.borderWidth) / 2
+		l.CellOffset = float64(options.borderWidth) / 2
 	}
+	return l
+}
 
-	dc := gg.NewContext(width, height)
-	dc.SetColor(options.backgroundColor)
-	dc.Clear()
+// cell returns the top left of the cell at the given grid position.
+func (l *Layout) cell(gridX, gridY int) (float64, float64) {
+	return l.CellOffset + float64(gridX)*l.CellWidth, l.CellOffset + float64(gridY)*l.CellHeight
+}
 
-	pendingWord := map[int]Cell{}
-	if best := c.BestPendingWord(); best != nil {
-		for _, c := range best.Result.Cells {
-			pendingWord[c.Index] = c
+// RenderLayers fills the background then draws each layer in order.
+func RenderLayers(r Renderer, l *Layout, layers ...Layer) error {
+	r.Clear(l.options.backgroundColor)
+	for _, layer := range layers {
+		if err := layer.Draw(r, l); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	// board
-
-	for gridY := 0; gridY < len(c.Board); gridY++ {
-		for gridX, cell := range c.Board[gridY] {
-
-			// draw cell with border
-			cellColor := getBonusColour(options.cellBackgroundColor, cell.Bonus)
-			specialColor := getBonusColour(options.wordColor, cell.Bonus)
-
-			dc.SetColor(cellColor)
-			dc.DrawRectangle(cellOffset+(float64(gridX)*cellWidth), cellOffset+(float64(gridY)*cellHeight), cellWidth, cellHeight)
-			dc.FillPreserve()
-
-			dc.SetColor(options.wordColor)
-			dc.SetLineWidth(0.3)
-			dc.Stroke()
-
-			pendingCell, pending := pendingWord[cell.Index]
-
-			if !cell.Empty() || (pending) {
-				dc.DrawRectangle(cellOffset+(float64(gridX)*cellWidth), cellOffset+(float64(gridY)*cellHeight), cellWidth, cellHeight)
-				dc.SetColor(options.wordBackgroundColor)
-				dc.FillPreserve()
-
-				cellContent := cell.String()
-				if pending {
-					cellContent = pendingCell.String()
-					dc.SetColor(colornames.Green)
-				} else {
-					dc.SetColor(options.wordColor)
-				}
-
-				// draw the word
-				dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 26}))
-				dc.DrawStringAnchored(
-					strings.ToUpper(cellContent),
-					cellOffset+float64(gridX)*cellWidth+cellWidth/2,
-					cellOffset+float64(gridY)*cellHeight+cellHeight/2,
-					0.5,
-					0.5,
-				)
-
-				cellScore := cell.LetterScoreString()
-				if pending {
-					cellScore = pendingCell.LetterScoreString()
-				}
-
-				// draw letter score
-				dc.SetColor(specialColor)
-				dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 14}))
-				dc.DrawStringAnchored(
-					cellScore,
-					cellOffset+float64(gridX)*cellWidth+cellWidth-12,
-					cellOffset+float64(gridY)*cellHeight+cellHeight-12,
-					0.5,
-					0.5,
-				)
-
-				dc.Stroke()
-			}
-
-			// draw cell index
-			dc.SetColor(color.RGBA{107, 107, 99, 255})
-			dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 14}))
-			dc.DrawStringAnchored(
-				cell.IndexString(),
-				cellOffset+float64(gridX)*cellWidth+12,
-				cellOffset+float64(gridY)*cellHeight+12,
-				0.5,
-				0.5,
-			)
+// RenderClassic draws the game onto any renderer.
+func RenderClassic(r Renderer, c *Classic, opts ...RenderOption) error {
+	width, height := r.Size()
+	l := NewLayout(width, height, len(c.Board), opts...)
 
+	scores := &TextPanel{Heading: "PLAYER SCORES", Y: l.PanelY + 180, LinesY: l.PanelY + 215, LineHeight: 25}
+	for _, p := range c.Players {
+		if c.getCurrentPlayerName() == p.Name {
+			scores.Lines = append(scores.Lines, PanelLine{Text: fmt.Sprintf("%s: %d [current player]", p.Name, p.Score), Color: colornames.Darkblue})
+		} else {
+			scores.Lines = append(scores.Lines, PanelLine{Text: fmt.Sprintf("%s: %d", p.Name, p.Score), Color: colornames.Black})
 		}
 	}
+	layers := []Layer{
+		&BoardLayer{Board: c.Board, Style: TileStyle{LetterSize: 24, ScoreSize: 12}},
+		LegendPanel(l.PanelY+20, l.PanelY+50, TripleWordScoreType, DoubleWordScoreType, TripleLetterScoreType, DoubleLetterScoreType),
+		&TextPanel{Heading: fmt.Sprintf("TILES LEFT: %d", c.SpareLetters.Len()), Y: l.PanelY + 150},
+		scores,
+	}
+	if l.options.tileTracker {
+		tracker, err := tileTrackerPanel(c, l.PanelY+250+25*float64(len(c.Players)))
+		if err != nil {
+			return err
+		}
+		layers = append(layers, tracker)
+	}
+	return RenderLayers(r, l, layers...)
+}
 
-	suffix := "[IDLE]"
-	if c.GameState == StateStealing && c.PlaceWordAt != nil {
-		suffix = fmt.Sprintf("[COUNTDOWN %s]", time.Until(*c.PlaceWordAt).Truncate(time.Second))
+func tileTrackerPanel(c *Classic, y float64) (*TextPanel, error) {
+	unseen, err := c.UnseenTiles(c.CurrentPlayer)
+	if err != nil {
+		return nil, err
+	}
+	total := 0
+	for _, count := range unseen {
+		total += count
 	}
+	panel := &TextPanel{Heading: fmt.Sprintf("UNSEEN TILES: %d", total), Y: y, LinesY: y + 25, LineHeight: 25}
+
+	letters := slices.Sorted(maps.Keys(unseen))
+	const perLine = 6
+	for i := 0; i < len(letters); i += perLine {
+		line := []string{}
+		for _, l := range letters[i:min(i+perLine, len(letters))] {
+			line = append(line, fmt.Sprintf("%c:%d", l, unseen[l]))
+		}
+		panel.Lines = append(panel.Lines, PanelLine{Text: strings.Join(line, "  "), Color: color.Black})
+	}
+	return panel, nil
+}
 
-	xOffset := float64(gridWidth) + float64(options.borderWidth)
+// RenderScrabulous draws the game onto any renderer.
+func RenderScrabulous(r Renderer, c *Scrabulous, opts ...RenderOption) error {
+	width, height := r.Size()
+	l := NewLayout(width, height, len(c.Board), opts...)
+	style := TileStyle{LetterSize: 26, ScoreSize: 14, BonusScoreColor: true}
 
-	dc.SetColor(color.Black)
-	dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 20}))
-	dc.DrawString(
-		fmt.Sprintf("LETTERS (%d spare) | %s", len(c.SpareLetters), suffix),
-		xOffset,
-		50,
-	)
-	dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 18}))
-	dc.SetColor(colornames.Black)
-	for i, v := range c.Letters {
-		dc.SetColor(options.wordBackgroundColor)
-		dc.DrawRectangle(xOffset+float64(60*i), 50+float64(options.borderWidth)/2, 55, 55)
-		dc.Fill()
-
-		dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 18}))
-		dc.SetColor(colornames.Black)
-		dc.DrawStringAnchored(
-			string(v),
-			xOffset+float64(60*i)+30,
-			50+float64(options.borderWidth)/2+30,
-			0.5,
-			0.5,
-		)
-
-		dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 10}))
-		dc.SetColor(colornames.Black)
-		dc.DrawStringAnchored(
-			fmt.Sprintf("%d", LetterScores[v]),
-			xOffset+float64(60*i)+45,
-			50+float64(options.borderWidth)/2+45,
-			0.5,
-			0.5,
-		)
+	board := &BoardLayer{Board: c.Board, Style: style, PendingColor: colornames.Green}
+	if best := c.BestPendingWord(); best != nil {
+		board.Pending = best.Result.Cells
 	}
 
-	//scores
-	dc.SetColor(color.Black)
-	dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 20}))
-	dc.DrawString(
-		"PLAYER SCORES",
-		float64(gridWidth)+float64(options.borderWidth),
-		150+float64(options.borderWidth)/2,
-	)
+	suffix := "[IDLE]"
+	if c.GameState == StateStealing && c.PlaceWordAt != nil {
+		suffix = fmt.Sprintf("[COUNTDOWN %s]", c.StealTimeRemaining().Truncate(time.Second))
+	}
 
-	dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 18}))
-	dc.SetColor(colornames.Black)
-	for i, score := range c.GetScores() {
+	scores := &TextPanel{Heading: "PLAYER SCORES", Y: l.PanelY + 150, LinesY: l.PanelY + 180, LineHeight: 30}
+	for _, score := range c.GetScores() {
+		var scoreColor color.Color = colornames.Black
 		if !c.IsPlayerAllowed(score.PlayerName) {
-			dc.SetColor(colornames.Red)
-		} else {
-			dc.SetColor(colornames.Black)
+			scoreColor = colornames.Red
 		}
-		dc.DrawString(
-			fmt.Sprintf("%s: %d (%d words)", score.PlayerName, score.Score, score.Words),
-			float64(gridWidth)+float64(options.borderWidth),
-			150+float64(options.borderWidth)/2+(30*float64(i+1)),
-		)
+		scores.Lines = append(scores.Lines, PanelLine{
+			Text:  fmt.Sprintf("%s: %d (%d words)", score.PlayerName, score.Score, score.Words),
+			Color: scoreColor,
+		})
 	}
 
-	// tile legend
-	dc.SetColor(color.Black)
-	dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 20}))
-	dc.DrawString(
-		"LEGEND",
-		float64(gridWidth)+float64(options.borderWidth),
-		(float64(gridHeight)-80)+float64(options.borderWidth)/2,
+	return RenderLayers(
+		r,
+		l,
+		board,
+		&RackLayer{Heading: fmt.Sprintf("LETTERS (%d spare) | %s", c.SpareLetters.Len(), suffix), Y: l.PanelY + 40, TilesY: l.PanelY + 50, Letters: c.Letters},
+		scores,
+		LegendPanel(l.GridHeight-80+l.PanelY, l.GridHeight-60+l.PanelY, DoubleLetterScoreType, TripleLetterScoreType, DoubleWordScoreType, TripleWordScoreType),
 	)
-	dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: 18}))
-	for i, legend := range []struct {
-		name   string
-		colour color.Color
-	}{
-		{name: "Triple Word Score", colour: color.RGBA{R: 208, G: 44, B: 32, A: 255}},
-		{name: "Double Word Score", colour: color.RGBA{R: 216, G: 143, B: 139, A: 255}},
-		{name: "Triple Letter Score", colour: color.RGBA{R: 84, G: 164, B: 198, A: 255}},
-		{name: "Double Letter Score", colour: color.RGBA{R: 183, G: 215, B: 230, A: 255}},
-	} {
-		dc.SetColor(legend.colour)
-		dc.DrawString(
-			legend.name,
-			float64(gridWidth)+float64(options.borderWidth),
-			(float64(gridHeight)-20*float64(i))+float64(options.borderWidth)/2,
-		)
+}
+
+func RenderClassicPNG(c *Classic, width, height int, opts ...RenderOption) (*gg.Context, error) {
+	r := NewPNGRenderer(width, height)
+	if err := RenderClassic(r, c, opts...); err != nil {
+		return nil, err
 	}
+	return r.Context(), nil
+}
 
-	return dc, nil
+func RenderScrabulousPNG(c *Scrabulous, width, height int, opts ...RenderOption) (*gg.Context, error) {
+	r := NewPNGRenderer(width, height)
+	if err := RenderScrabulous(r, c, opts...); err != nil {
+		return nil, err
+	}
+	return r.Context(), nil
 }
 
 func getBonusColour(def color.Color, bonus CellBonusType) color.Color {
@@ -0,0 +1,326 @@
+package scrabble
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"maps"
+	"slices"
+	"time"
+
+	"golang.org/x/image/colornames"
+)
+
+// replayHeight is the height that replay frames are laid out at before they are scaled to the frame size.
+const replayHeight = 1000
+
+type GIFOption func(opts *gifOpts)
+
+type gifOpts struct {
+	width      int
+	height     int
+	delay      time.Duration
+	finalDelay time.Duration
+	render     []RenderOption
+}
+
+func resolveGIFOptions(opts ...GIFOption) *gifOpts {
+	opt := &gifOpts{
+		width:      750,
+		height:     500,
+		delay:      time.Second,
+		finalDelay: time.Second * 3,
+	}
+	for _, v := range opts {
+		v(opt)
+	}
+	return opt
+}
+
+// WithFrameSize sets the size of the animation in pixels. Frames are laid out in the same way as RenderClassicPNG
+// and scaled to fit the height so a narrow frame may cut off the panel beside the board.
+func WithFrameSize(width, height int) GIFOption {
+	return func(opts *gifOpts) {
+		opts.width = width
+		opts.height = height
+	}
+}
+
+// WithFrameDelay sets how long each move is shown for.
+func WithFrameDelay(delay time.Duration) GIFOption {
+	return func(opts *gifOpts) {
+		opts.delay = delay
+	}
+}
+
+// WithFinalFrameDelay sets how long the last move is shown for before the animation starts again.
+func WithFinalFrameDelay(delay time.Duration) GIFOption {
+	return func(opts *gifOpts) {
+		opts.finalDelay = delay
+	}
+}
+
+// WithFrameRenderOptions sets the options used to draw each frame e.g. colours.
+func WithFrameRenderOptions(opts ...RenderOption) GIFOption {
+	return func(gifOpts *gifOpts) {
+		gifOpts.render = append(gifOpts.render, opts...)
+	}
+}
+
+// replayFrame is the state of a game after a move.
+type replayFrame struct {
+	board   Board
+	caption []string
+	scores  []PanelLine
+}
+
+// RenderClassicGIF animates the game's history. The first frame is the empty board then each frame shows the board
+// after a move with the tiles it placed highlighted. Undone moves are left out.
+func RenderClassicGIF(c *Classic, opts ...GIFOption) (*gif.GIF, error) {
+	replayed := NewClassicGame()
+	frames := []replayFrame{classicFrame(replayed, nil)}
+	for i, e := range c.Events() {
+		moves := len(replayed.History)
+		if err := replayed.apply(e); err != nil {
+			return nil, fmt.Errorf("failed to replay event %d (%s): %w", i, e.EventType(), err)
+		}
+		// frames after the first are one per move in the history, an undo removes moves and a new turn or a redo
+		// adds them back
+		kept := min(moves, len(replayed.History))
+		frames = frames[:kept+1]
+		for _, move := range replayed.History[kept:] {
+			frames = append(frames, classicFrame(replayed, move))
+		}
+	}
+	if replayed.Complete {
+		result, err := replayed.Result()
+		if err != nil {
+			return nil, err
+		}
+		last := &frames[len(frames)-1]
+		// the final scores include the value of the tiles left on each rack
+		var lastMove *Move
+		if len(replayed.History) > 0 {
+			lastMove = replayed.History[len(replayed.History)-1]
+		}
+		last.scores = classicFrame(replayed, lastMove).scores
+		if result.Tied {
+			last.caption = append(last.caption, "GAME OVER: the game is tied")
+		} else {
+			last.caption = append(last.caption, fmt.Sprintf("GAME OVER: %s wins", result.Winner))
+		}
+	}
+	return renderGIF(frames, resolveGIFOptions(opts...))
+}
+
+func classicFrame(g *Classic, move *Move) replayFrame {
+	frame := replayFrame{board: g.Board.clone(), caption: []string{"Game start"}}
+	mover := ""
+	if move != nil {
+		mover = move.Player
+		frame.caption = []string{moveCaption(move)}
+	}
+	for _, p := range g.Players {
+		frame.scores = append(frame.scores, scoreLine(fmt.Sprintf("%s: %d", p.Name, p.Score), p.Name == mover))
+	}
+	return frame
+}
+
+func moveCaption(move *Move) string {
+	switch move.Type {
+	case TurnPlay:
+		return fmt.Sprintf("%s played %s at %s for %d", move.Player, move.Word, move.Placement.Coordinate(), move.Score)
+	case TurnExchange:
+		return fmt.Sprintf("%s exchanged %d tiles", move.Player, len(move.Exchanged))
+	case TurnPass:
+		return fmt.Sprintf("%s passed", move.Player)
+	case TurnWithdrawn:
+		return fmt.Sprintf("%s's play %s was withdrawn", move.Player, move.Word)
+	case TurnChallengeLost:
+		return fmt.Sprintf("%s lost a challenge", move.Player)
+	case TurnChallengeBonus:
+		return fmt.Sprintf("%s scored %d from a challenge", move.Player, move.Score)
+	}
+	return fmt.Sprintf("%s: %s", move.Player, move.Type)
+}
+
+// RenderScrabulousGIF animates the words placed in the game in the same way as RenderClassicGIF.
+func RenderScrabulousGIF(s *Scrabulous, opts ...GIFOption) (*gif.GIF, error) {
+	replayed := NewScrabulousGame(s.StealTime)
+	frames := []replayFrame{scrabulousFrame(replayed, nil)}
+	for i, e := range s.Events() {
+		if err := replayed.apply(e); err != nil {
+			return nil, fmt.Errorf("failed to replay event %d (%s): %w", i, e.EventType(), err)
+		}
+		if placed, ok := e.(WordPlaced); ok {
+			frames = append(frames, scrabulousFrame(replayed, &placed))
+		}
+	}
+	return renderGIF(frames, resolveGIFOptions(opts...))
+}
+
+func scrabulousFrame(s *Scrabulous, placed *WordPlaced) replayFrame {
+	frame := replayFrame{board: s.Board.clone(), caption: []string{"Game start"}}
+	mover := ""
+	if placed != nil {
+		mover = placed.Player
+		frame.caption = []string{
+			fmt.Sprintf("%s played %s at %s for %d", placed.Player, placed.Word, placed.Placement.Coordinate(), placed.Score),
+		}
+	}
+	for _, score := range s.GetScores() {
+		frame.scores = append(
+			frame.scores,
+			scoreLine(fmt.Sprintf("%s: %d (%d words)", score.PlayerName, score.Score, score.Words), score.PlayerName == mover),
+		)
+	}
+	return frame
+}
+
+func scoreLine(text string, mover bool) PanelLine {
+	if mover {
+		return PanelLine{Text: text, Color: colornames.Darkblue}
+	}
+	return PanelLine{Text: text, Color: colornames.Black}
+}
+
+func renderGIF(frames []replayFrame, options *gifOpts) (*gif.GIF, error) {
+	if options.width <= 0 || options.height <= 0 {
+		return nil, fmt.Errorf("invalid frame size %dx%d", options.width, options.height)
+	}
+	scale := float64(options.height) / replayHeight
+
+	draw := func(i int) (*image.RGBA, error) {
+		frame := frames[i]
+		highlight := make(map[int]bool)
+		if i > 0 {
+			for y, row := range frame.board {
+				for x, cell := range row {
+					if !cell.Empty() && frames[i-1].board[y][x].Char != cell.Char {
+						highlight[cell.Index] = true
+					}
+				}
+			}
+		}
+		r := NewScaledPNGRenderer(options.width, options.height, scale)
+		width, height := r.Size()
+		l := NewLayout(width, height, len(frame.board), options.render...)
+		err := RenderLayers(
+			r,
+			l,
+			&BoardLayer{Board: frame.board, Style: TileStyle{LetterSize: 24, ScoreSize: 12}, Highlight: highlight},
+			&TextPanel{Heading: replayHeading(i, len(frames)), Y: l.PanelY + 20, LinesY: l.PanelY + 50, LineHeight: 25, Lines: captionLines(frame.caption)},
+			&TextPanel{Heading: "PLAYER SCORES", Y: l.PanelY + 130, LinesY: l.PanelY + 165, LineHeight: 25, Lines: frame.scores},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return r.Context().Image().(*image.RGBA), nil
+	}
+
+	// the last frame has every tile and colour used so its most common colours make the palette for all of them
+	last, err := draw(len(frames) - 1)
+	if err != nil {
+		return nil, err
+	}
+	quantizer := newGIFQuantizer(last)
+
+	anim := &gif.GIF{}
+	var previous *image.Paletted
+	for i := range frames {
+		img := last
+		if i < len(frames)-1 {
+			if img, err = draw(i); err != nil {
+				return nil, err
+			}
+		}
+		paletted := quantizer.convert(img)
+		delay := options.delay
+		if i == len(frames)-1 {
+			delay = options.finalDelay
+		}
+		anim.Image = append(anim.Image, changedArea(previous, paletted))
+		anim.Delay = append(anim.Delay, int(delay/(10*time.Millisecond)))
+		previous = paletted
+	}
+	return anim, nil
+}
+
+func replayHeading(frame int, frames int) string {
+	if frame == 0 {
+		return fmt.Sprintf("%d MOVES", frames-1)
+	}
+	return fmt.Sprintf("MOVE %d OF %d", frame, frames-1)
+}
+
+func captionLines(caption []string) []PanelLine {
+	lines := make([]PanelLine, 0, len(caption))
+	for _, v := range caption {
+		lines = append(lines, PanelLine{Text: v, Color: color.Black})
+	}
+	return lines
+}
+
+// changedArea returns the part of the frame that differs from the previous one, the rest of the previous frame is
+// left in place when the animation is played.
+func changedArea(previous *image.Paletted, frame *image.Paletted) *image.Paletted {
+	if previous == nil {
+		return frame
+	}
+	changed := image.Rectangle{}
+	bounds := frame.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if frame.ColorIndexAt(x, y) != previous.ColorIndexAt(x, y) {
+				changed = changed.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	if changed.Empty() {
+		// the frame is still needed for its delay
+		changed = image.Rect(0, 0, 1, 1)
+	}
+	return frame.SubImage(changed).(*image.Paletted)
+}
+
+// gifQuantizer maps colours to a palette of at most 256 colours.
+type gifQuantizer struct {
+	palette color.Palette
+	indexes map[color.RGBA]uint8
+}
+
+// newGIFQuantizer makes a palette from the most common colours in the image.
+func newGIFQuantizer(img *image.RGBA) *gifQuantizer {
+	counts := make(map[color.RGBA]int)
+	for i := 0; i < len(img.Pix); i += 4 {
+		counts[color.RGBA{R: img.Pix[i], G: img.Pix[i+1], B: img.Pix[i+2], A: 255}]++
+	}
+	colours := slices.SortedFunc(maps.Keys(counts), func(a, b color.RGBA) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		// colours that are as common as each other are ordered so the palette is always the same
+		return int(a.R)<<16 + int(a.G)<<8 + int(a.B) - (int(b.R)<<16 + int(b.G)<<8 + int(b.B))
+	})
+	q := &gifQuantizer{indexes: make(map[color.RGBA]uint8)}
+	for _, c := range colours[:min(len(colours), 256)] {
+		q.indexes[c] = uint8(len(q.palette))
+		q.palette = append(q.palette, c)
+	}
+	return q
+}
+
+func (q *gifQuantizer) convert(img *image.RGBA) *image.Paletted {
+	out := image.NewPaletted(img.Bounds(), q.palette)
+	for i, j := 0, 0; i < len(img.Pix); i, j = i+4, j+1 {
+		c := color.RGBA{R: img.Pix[i], G: img.Pix[i+1], B: img.Pix[i+2], A: 255}
+		index, ok := q.indexes[c]
+		if !ok {
+			index = uint8(q.palette.Index(c))
+			q.indexes[c] = index
+		}
+		out.Pix[j] = index
+	}
+	return out
+}
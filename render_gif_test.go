@@ -0,0 +1,73 @@
+package scrabble
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// playFromRack plays the first three tiles in the current player's rack that are not blanks.
+func playFromRack(t *testing.T, game *Classic, placement Placement) {
+	t.Helper()
+	player, err := game.GetCurrentPlayer()
+	if err != nil {
+		t.Fatalf("GetCurrentPlayer() error = %v", err)
+	}
+	word := []rune{}
+	for _, l := range player.Letters {
+		if l != blankLetter && len(word) < 3 {
+			word = append(word, l)
+		}
+	}
+	if err := game.PlaceWord(placement, string(word)); err != nil {
+		t.Fatalf("PlaceWord() error = %v", err)
+	}
+}
+
+func TestRenderClassicGIF(t *testing.T) {
+	game := newTestClassicGame(t, 5, "alice", "bob")
+	playFromRack(t, game, Placement{CellId: 112, Direction: Across})
+	if err := game.Pass(); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+	if err := game.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	playFromRack(t, game, Placement{CellId: 127, Direction: Across})
+
+	anim, err := RenderClassicGIF(game, WithFrameDelay(time.Millisecond*500), WithFrameSize(750, 500))
+	if err != nil {
+		t.Fatalf("RenderClassicGIF() error = %v", err)
+	}
+	// the undone pass is left out
+	if want := []int{50, 50, 300}; !reflect.DeepEqual(anim.Delay, want) {
+		t.Fatalf("got delays %v, want %v", anim.Delay, want)
+	}
+	if got := anim.Image[0].Bounds(); got != image.Rect(0, 0, 750, 500) {
+		t.Errorf("got first frame bounds %v", got)
+	}
+
+	// a point on the tile in cell 112 which is highlighted only in the frame for the move that placed it
+	x, y := 202, 248
+	tileColor := func(frame int) color.RGBA {
+		if !image.Pt(x, y).In(anim.Image[frame].Bounds()) {
+			t.Fatalf("frame %d does not include the tile", frame)
+		}
+		return color.RGBAModel.Convert(anim.Image[frame].At(x, y)).(color.RGBA)
+	}
+	opts := resolveRenderOptions()
+	if got := tileColor(1); got != opts.highlightColor {
+		t.Errorf("got tile color %v after the tile was placed, want %v", got, opts.highlightColor)
+	}
+	if got := tileColor(2); got != opts.wordBackgroundColor {
+		t.Errorf("got tile color %v after the next move, want %v", got, opts.wordBackgroundColor)
+	}
+
+	if err := gif.EncodeAll(&bytes.Buffer{}, anim); err != nil {
+		t.Errorf("gif.EncodeAll() error = %v", err)
+	}
+}
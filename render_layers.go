@@ -0,0 +1,136 @@
+package scrabble
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+)
+
+// TileStyle sets how tiles on the board are drawn.
+type TileStyle struct {
+	LetterSize float64
+	ScoreSize  float64
+	// BonusScoreColor draws the letter score of a tile on a premium square in the colour of the square.
+	BonusScoreColor bool
+}
+
+// BoardLayer draws the cells of the board and the tiles placed on them.
+type BoardLayer struct {
+	Board Board
+	Style TileStyle
+	// Highlight gives the indexes of cells whose tiles are drawn with the highlight colour.
+	Highlight map[int]bool
+	// Pending gives tiles that have not been placed yet, they are drawn in PendingColor in place of the board cells.
+	Pending      []Cell
+	PendingColor color.Color
+}
+
+func (b *BoardLayer) Draw(r Renderer, l *Layout) error {
+	pending := make(map[int]Cell, len(b.Pending))
+	for _, cell := range b.Pending {
+		pending[cell.Index] = cell
+	}
+	for gridY, row := range b.Board {
+		for gridX, cell := range row {
+			x, y := l.cell(gridX, gridY)
+			r.Rect(x, y, l.CellWidth, l.CellHeight, getBonusColour(l.options.cellBackgroundColor, cell.Bonus), l.options.wordColor, 0.3)
+			if pendingCell, ok := pending[cell.Index]; ok {
+				drawTile(r, l, x, y, pendingCell, b.Style, l.options.wordBackgroundColor, b.PendingColor)
+			} else if !cell.Empty() {
+				letterColor := l.options.wordColor
+				if cell.IsBlank {
+					letterColor = l.options.blankColor
+				}
+				background := l.options.wordBackgroundColor
+				if b.Highlight[cell.Index] {
+					background = l.options.highlightColor
+				}
+				drawTile(r, l, x, y, cell, b.Style, background, letterColor)
+			}
+			drawCellIndex(r, l, x, y, cell)
+		}
+	}
+	if l.options.coordinateLabels {
+		labelColor := color.RGBA{107, 107, 99, 255}
+		for i := range b.Board {
+			r.TextCentred(string(rune('A'+i)), l.CellOffset+float64(i)*l.CellWidth+l.CellWidth/2, l.CellOffset/2, 16, labelColor)
+			r.TextCentred(fmt.Sprintf("%d", i+1), l.CellOffset/2, l.CellOffset+float64(i)*l.CellHeight+l.CellHeight/2, 16, labelColor)
+		}
+	}
+	return nil
+}
+
+func drawTile(r Renderer, l *Layout, x, y float64, cell Cell, style TileStyle, background color.Color, letterColor color.Color) {
+	scoreColor := l.options.wordColor
+	if style.BonusScoreColor {
+		scoreColor = getBonusColour(scoreColor, cell.Bonus)
+	}
+	r.Rect(x, y, l.CellWidth, l.CellHeight, background, scoreColor, 0.3)
+	r.TextCentred(strings.ToUpper(cell.String()), x+l.CellWidth/2, y+l.CellHeight/2, style.LetterSize, letterColor)
+	r.TextCentred(cell.LetterScoreString(), x+l.CellWidth-12, y+l.CellHeight-12, style.ScoreSize, scoreColor)
+}
+
+// drawCellIndex labels the cell with its index unless the board has coordinate labels instead.
+func drawCellIndex(r Renderer, l *Layout, x, y float64, cell Cell) {
+	if !l.options.coordinateLabels {
+		r.TextCentred(cell.IndexString(), x+12, y+12, 14, color.RGBA{107, 107, 99, 255})
+	}
+}
+
+// RackLayer draws a heading followed by a row of tiles in the panel beside the board.
+type RackLayer struct {
+	Heading string
+	// Y is the baseline of the heading and TilesY is the top of the tiles.
+	Y       float64
+	TilesY  float64
+	Letters []rune
+}
+
+func (k *RackLayer) Draw(r Renderer, l *Layout) error {
+	r.Text(k.Heading, l.PanelX, k.Y, 20, color.Black)
+	for i, v := range k.Letters {
+		x := l.PanelX + float64(60*i)
+		r.Rect(x, k.TilesY, 55, 55, l.options.wordBackgroundColor, nil, 0)
+		r.TextCentred(string(v), x+30, k.TilesY+30, 18, color.Black)
+		r.TextCentred(fmt.Sprintf("%d", LetterScores[v]), x+45, k.TilesY+45, 10, color.Black)
+	}
+	return nil
+}
+
+// TextPanel draws a heading followed by lines of text in the panel beside the board e.g. the scores.
+type TextPanel struct {
+	Heading string
+	// Y is the baseline of the heading and LinesY is the baseline of the first line.
+	Y          float64
+	LinesY     float64
+	LineHeight float64
+	Lines      []PanelLine
+}
+
+type PanelLine struct {
+	Text  string
+	Color color.Color
+}
+
+func (t *TextPanel) Draw(r Renderer, l *Layout) error {
+	r.Text(t.Heading, l.PanelX, t.Y, 20, color.Black)
+	for i, line := range t.Lines {
+		r.Text(line.Text, l.PanelX, t.LinesY+t.LineHeight*float64(i), 18, line.Color)
+	}
+	return nil
+}
+
+// LegendPanel lists the premium squares in the given order, each in the colour used for it on the board.
+func LegendPanel(y float64, linesY float64, bonuses ...CellBonusType) *TextPanel {
+	names := map[CellBonusType]string{
+		TripleWordScoreType:   "Triple Word Score",
+		DoubleWordScoreType:   "Double Word Score",
+		TripleLetterScoreType: "Triple Letter Score",
+		DoubleLetterScoreType: "Double Letter Score",
+	}
+	panel := &TextPanel{Heading: "LEGEND", Y: y, LinesY: linesY, LineHeight: 20}
+	for _, bonus := range bonuses {
+		panel.Lines = append(panel.Lines, PanelLine{Text: names[bonus], Color: getBonusColour(color.Black, bonus)})
+	}
+	return panel
+}
@@ -0,0 +1,76 @@
+package scrabble
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/fogleman/gg"
+	"github.com/golang/freetype/truetype"
+	xfont "golang.org/x/image/font"
+)
+
+// PNGRenderer draws raster images using gg.
+type PNGRenderer struct {
+	dc    *gg.Context
+	scale float64
+	faces map[float64]xfont.Face
+}
+
+func NewPNGRenderer(width, height int) *PNGRenderer {
+	return NewScaledPNGRenderer(width, height, 1)
+}
+
+// NewScaledPNGRenderer draws an image of the given size in pixels with everything drawn on it, including text,
+// scaled. Layers see the size of the image before it is scaled so a layout can be drawn at any size.
+func NewScaledPNGRenderer(width, height int, scale float64) *PNGRenderer {
+	return &PNGRenderer{dc: gg.NewContext(width, height), scale: scale, faces: make(map[float64]xfont.Face)}
+}
+
+// Context gives the drawn image e.g. to save it with SavePNG.
+func (r *PNGRenderer) Context() *gg.Context {
+	return r.dc
+}
+
+func (r *PNGRenderer) Size() (int, int) {
+	return int(math.Round(float64(r.dc.Width()) / r.scale)), int(math.Round(float64(r.dc.Height()) / r.scale))
+}
+
+func (r *PNGRenderer) Clear(cl color.Color) {
+	r.dc.SetColor(cl)
+	r.dc.Clear()
+}
+
+func (r *PNGRenderer) Rect(x, y, w, h float64, fill color.Color, stroke color.Color, strokeWidth float64) {
+	r.dc.DrawRectangle(x*r.scale, y*r.scale, w*r.scale, h*r.scale)
+	if fill != nil {
+		r.dc.SetColor(fill)
+		r.dc.FillPreserve()
+	}
+	if stroke != nil {
+		r.dc.SetColor(stroke)
+		r.dc.SetLineWidth(strokeWidth * r.scale)
+		r.dc.StrokePreserve()
+	}
+	r.dc.ClearPath()
+}
+
+func (r *PNGRenderer) Text(s string, x, y, size float64, cl color.Color) {
+	r.setText(size, cl)
+	r.dc.DrawString(s, x*r.scale, y*r.scale)
+}
+
+func (r *PNGRenderer) TextCentred(s string, x, y, size float64, cl color.Color) {
+	r.setText(size, cl)
+	r.dc.DrawStringAnchored(s, x*r.scale, y*r.scale, 0.5, 0.5)
+}
+
+func (r *PNGRenderer) setText(size float64, cl color.Color) {
+	size *= r.scale
+	face, ok := r.faces[size]
+	if !ok {
+		face = truetype.NewFace(font, &truetype.Options{Size: size})
+		r.faces[size] = face
+	}
+	r.dc.SetFontFace(face)
+	r.dc.SetColor(cl)
+}
@@ -0,0 +1,100 @@
+package scrabble
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+)
+
+// SVGRenderer draws resolution independent images using the same coordinates and font sizes as PNGRenderer.
+type SVGRenderer struct {
+	width  int
+	height int
+	buf    bytes.Buffer
+}
+
+func NewSVGRenderer(width, height int) *SVGRenderer {
+	return &SVGRenderer{width: width, height: height}
+}
+
+// Bytes gives the drawn SVG document.
+func (r *SVGRenderer) Bytes() []byte {
+	out := &bytes.Buffer{}
+	fmt.Fprintf(
+		out,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Go, sans-serif">`+"\n",
+		r.width, r.height, r.width, r.height,
+	)
+	out.Write(r.buf.Bytes())
+	out.WriteString("</svg>\n")
+	return out.Bytes()
+}
+
+func (r *SVGRenderer) Size() (int, int) {
+	return r.width, r.height
+}
+
+func (r *SVGRenderer) Clear(cl color.Color) {
+	fmt.Fprintf(&r.buf, `<rect width="100%%" height="100%%" %s/>`+"\n", svgPaint("fill", cl))
+}
+
+func (r *SVGRenderer) Rect(x, y, w, h float64, fill color.Color, stroke color.Color, strokeWidth float64) {
+	fmt.Fprintf(&r.buf, `<rect x="%s" y="%s" width="%s" height="%s"`, svgNum(x), svgNum(y), svgNum(w), svgNum(h))
+	if fill != nil {
+		r.buf.WriteString(" " + svgPaint("fill", fill))
+	} else {
+		r.buf.WriteString(` fill="none"`)
+	}
+	if stroke != nil {
+		fmt.Fprintf(&r.buf, ` %s stroke-width="%s"`, svgPaint("stroke", stroke), svgNum(strokeWidth))
+	}
+	r.buf.WriteString("/>\n")
+}
+
+func (r *SVGRenderer) Text(s string, x, y, size float64, cl color.Color) {
+	r.text(s, x, y, size, cl, "")
+}
+
+func (r *SVGRenderer) TextCentred(s string, x, y, size float64, cl color.Color) {
+	r.text(s, x, y, size, cl, ` text-anchor="middle" dominant-baseline="central"`)
+}
+
+func (r *SVGRenderer) text(s string, x, y, size float64, cl color.Color, attrs string) {
+	fmt.Fprintf(&r.buf, `<text x="%s" y="%s" font-size="%s" %s%s>`, svgNum(x), svgNum(y), svgNum(size), svgPaint("fill", cl), attrs)
+	// writing to a bytes.Buffer cannot fail
+	_ = xml.EscapeText(&r.buf, []byte(s))
+	r.buf.WriteString("</text>\n")
+}
+
+func svgNum(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
+
+func svgPaint(attr string, cl color.Color) string {
+	c := color.NRGBAModel.Convert(cl).(color.NRGBA)
+	paint := fmt.Sprintf(`%s="#%02x%02x%02x"`, attr, c.R, c.G, c.B)
+	if c.A != 255 {
+		paint += fmt.Sprintf(` %s-opacity="%g"`, attr, float64(c.A)/255)
+	}
+	return paint
+}
+
+// RenderClassicSVG draws the game in the same layout as RenderClassicPNG but as a resolution independent SVG image.
+func RenderClassicSVG(c *Classic, width, height int, opts ...RenderOption) ([]byte, error) {
+	r := NewSVGRenderer(width, height)
+	if err := RenderClassic(r, c, opts...); err != nil {
+		return nil, err
+	}
+	return r.Bytes(), nil
+}
+
+// RenderScrabulousSVG draws the game in the same layout as RenderScrabulousPNG but as a resolution independent SVG
+// image.
+func RenderScrabulousSVG(c *Scrabulous, width, height int, opts ...RenderOption) ([]byte, error) {
+	r := NewSVGRenderer(width, height)
+	if err := RenderScrabulous(r, c, opts...); err != nil {
+		return nil, err
+	}
+	return r.Bytes(), nil
+}
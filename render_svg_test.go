@@ -0,0 +1,75 @@
+package scrabble
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// svgText returns the text drawn by the SVG, failing the test if it is not well-formed.
+func svgText(t *testing.T, svg []byte) []string {
+	t.Helper()
+	texts := make([]string, 0)
+	dec := xml.NewDecoder(bytes.NewReader(svg))
+	inText := false
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return texts
+		}
+		if err != nil {
+			t.Fatalf("invalid SVG: %v", err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			inText = tok.Name.Local == "text"
+		case xml.CharData:
+			if inText {
+				texts = append(texts, string(tok))
+			}
+		case xml.EndElement:
+			inText = false
+		}
+	}
+}
+
+func TestRenderClassicSVG(t *testing.T) {
+	game := newTestClassicGame(t, 5, "alice", "b<o>b")
+	game.Players[0].Letters = []rune("CA_XYZQ")
+	if err := game.PlaceWord(Placement{CellId: 112, Direction: Across}, "CAt"); err != nil {
+		t.Fatalf("PlaceWord() error = %v", err)
+	}
+
+	svg, err := RenderClassicSVG(game, 1500, 1000, WithCoordinateLabels(), WithTileTracker())
+	if err != nil {
+		t.Fatalf("RenderClassicSVG() error = %v", err)
+	}
+	text := strings.Join(svgText(t, svg), "\n")
+	for _, want := range []string{"C", "T", "alice: 8", "b<o>b: 0 [current player]", "TILES LEFT: 83", "UNSEEN TILES: ", "Triple Word Score"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("SVG text does not contain %q", want)
+		}
+	}
+}
+
+func TestRenderScrabulousSVG(t *testing.T) {
+	game := newTestScrabulousGame(t, NewFakeClock(time.Now()), "CATS")
+	if _, err := game.CreatePendingWord(Placement{CellId: 112, Direction: Across}, "CATS", "alice"); err != nil {
+		t.Fatalf("CreatePendingWord() error = %v", err)
+	}
+
+	svg, err := RenderScrabulousSVG(game, 1500, 1000)
+	if err != nil {
+		t.Fatalf("RenderScrabulousSVG() error = %v", err)
+	}
+	text := strings.Join(svgText(t, svg), "\n")
+	for _, want := range []string{"S", "[COUNTDOWN 1m0s]", "PLAYER SCORES", "113", "LEGEND"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("SVG text does not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package scrabble
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func newRenderTestClassic(t *testing.T) *Classic {
+	t.Helper()
+	game := newTestClassicGame(t, 5, "alice", "bob")
+	game.Players[0].Letters = []rune("CA_XYZQ")
+	if err := game.PlaceWord(Placement{CellId: 112, Direction: Across}, "CAt"); err != nil {
+		t.Fatalf("PlaceWord() error = %v", err)
+	}
+	return game
+}
+
+func newRenderTestScrabulous(t *testing.T) *Scrabulous {
+	t.Helper()
+	game := newTestScrabulousGame(t, NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)), "CATS")
+	if _, err := game.CreatePendingWord(Placement{CellId: 112, Direction: Across}, "CAT", "alice"); err != nil {
+		t.Fatalf("CreatePendingWord() error = %v", err)
+	}
+	if err := game.PlacePendingWord(); err != nil {
+		t.Fatalf("PlacePendingWord() error = %v", err)
+	}
+	game.Letters = []rune("SDOG")
+	if _, err := game.CreatePendingWord(Placement{CellId: 115, Direction: Down}, "SDOG", "bob"); err != nil {
+		t.Fatalf("CreatePendingWord() error = %v", err)
+	}
+	return game
+}
+
+func TestRender_golden(t *testing.T) {
+	tests := []struct {
+		name   string
+		render func(t *testing.T) (image.Image, []byte, error)
+	}{
+		{
+			name: "classic.png",
+			render: func(t *testing.T) (image.Image, []byte, error) {
+				dc, err := RenderClassicPNG(newRenderTestClassic(t), 1500, 1000)
+				if err != nil {
+					return nil, nil, err
+				}
+				return dc.Image(), nil, nil
+			},
+		},
+		{
+			name: "classic-labels-tracker.png",
+			render: func(t *testing.T) (image.Image, []byte, error) {
+				dc, err := RenderClassicPNG(newRenderTestClassic(t), 1500, 1000, WithCoordinateLabels(), WithTileTracker())
+				if err != nil {
+					return nil, nil, err
+				}
+				return dc.Image(), nil, nil
+			},
+		},
+		{
+			name: "scrabulous.png",
+			render: func(t *testing.T) (image.Image, []byte, error) {
+				dc, err := RenderScrabulousPNG(newRenderTestScrabulous(t), 1500, 1000)
+				if err != nil {
+					return nil, nil, err
+				}
+				return dc.Image(), nil, nil
+			},
+		},
+		{
+			name: "classic.svg",
+			render: func(t *testing.T) (image.Image, []byte, error) {
+				svg, err := RenderClassicSVG(newRenderTestClassic(t), 1500, 1000, WithTileTracker())
+				return nil, svg, err
+			},
+		},
+		{
+			name: "scrabulous.svg",
+			render: func(t *testing.T) (image.Image, []byte, error) {
+				svg, err := RenderScrabulousSVG(newRenderTestScrabulous(t), 1500, 1000, WithCoordinateLabels())
+				return nil, svg, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, data, err := tt.render(t)
+			if err != nil {
+				t.Fatalf("render error = %v", err)
+			}
+			if img != nil {
+				buf := &bytes.Buffer{}
+				if err := png.Encode(buf, img); err != nil {
+					t.Fatalf("png.Encode() error = %v", err)
+				}
+				data = buf.Bytes()
+			}
+
+			path := filepath.Join("testdata", "render", tt.name)
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, data, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file, run the tests with -update to create it: %v", err)
+			}
+			if img == nil {
+				if !bytes.Equal(data, want) {
+					t.Errorf("%s does not match the golden file, run the tests with -update if the change is expected", tt.name)
+				}
+				return
+			}
+			// PNGs are compared by pixel so that changes to the encoder do not matter
+			wantImg, err := png.Decode(bytes.NewReader(want))
+			if err != nil {
+				t.Fatalf("invalid golden file: %v", err)
+			}
+			if diff := countPixelDiff(img, wantImg); diff != 0 {
+				t.Errorf("%s differs from the golden file by %d pixels, run the tests with -update if the change is expected", tt.name, diff)
+			}
+		})
+	}
+}
+
+func countPixelDiff(a, b image.Image) int {
+	if a.Bounds().Size() != b.Bounds().Size() {
+		return a.Bounds().Dx() * a.Bounds().Dy()
+	}
+	na, nb := image.NewNRGBA(a.Bounds()), image.NewNRGBA(a.Bounds())
+	draw.Draw(na, na.Bounds(), a, a.Bounds().Min, draw.Src)
+	draw.Draw(nb, nb.Bounds(), b, b.Bounds().Min, draw.Src)
+	diff := 0
+	for i := 0; i < len(na.Pix); i += 4 {
+		if !bytes.Equal(na.Pix[i:i+4], nb.Pix[i:i+4]) {
+			diff++
+		}
+	}
+	return diff
+}
@@ -0,0 +1,415 @@
+package scrabble
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Events returns every event recorded by the game so far.
+func (g *Classic) Events() []Event {
+	return slices.Clone(g.events)
+}
+
+// Replay resets the game and applies the first upTo events, or all of them if upTo is negative. The game's
+// options such as the lexicon are kept. Bots do not take their turns while events are replayed.
+func (g *Classic) Replay(events []Event, upTo int) error {
+	if upTo < 0 || upTo > len(events) {
+		upTo = len(events)
+	}
+	g.reset(g.ChallengeRule)
+	g.events = nil
+	for i, e := range events[:upTo] {
+		if err := g.apply(e); err != nil {
+			return fmt.Errorf("failed to replay event %d (%s): %w", i, e.EventType(), err)
+		}
+		g.events = append(g.events, e)
+	}
+	return nil
+}
+
+// record applies the event, adds it to the game's events and tells any subscribers.
+func (g *Classic) record(e Event) error {
+	before := g.observe()
+	if err := g.apply(e); err != nil {
+		return err
+	}
+	g.events = append(g.events, e)
+	g.subscribers.publish(append([]Event{e}, g.notifications(before)...)...)
+	return nil
+}
+
+func (g *Classic) apply(e Event) error {
+	switch e := e.(type) {
+	case GameStarted:
+		g.reset(e.ChallengeRule)
+	case PlayerJoined:
+		if _, err := g.getPlayerByName(e.Player); err == nil {
+			return fmt.Errorf("player %s has already joined", e.Player)
+		}
+		player := &Player{Name: e.Player, Letters: make([]rune, 0), Bot: e.strategy}
+		if e.Bot != "" && player.Bot == nil {
+			strategy, err := BotStrategyByName(e.Bot)
+			if err != nil {
+				return err
+			}
+			player.Bot = strategy
+		}
+		g.Players = append(g.Players, player)
+	case TilesDrawn:
+		player, err := g.getPlayerByName(e.Player)
+		if err != nil {
+			return err
+		}
+		if err := g.SpareLetters.Take([]rune(e.Tiles)...); err != nil {
+			return err
+		}
+		player.Letters = append(player.Letters, []rune(e.Tiles)...)
+	case TilesReturned:
+		player, err := g.getPlayerByName(e.Player)
+		if err != nil {
+			return err
+		}
+		if err := player.removeLetters([]rune(e.Tiles)); err != nil {
+			return err
+		}
+		g.SpareLetters.Return([]rune(e.Tiles)...)
+	case WordPlaced:
+		return g.applyWordPlaced(e)
+	case TilesExchanged:
+		return g.applyTilesExchanged(e)
+	case TurnPassed:
+		player, err := g.turnPlayer(e.Player)
+		if err != nil {
+			return err
+		}
+		g.saveUndoState(g.snapshot())
+		g.lastPlay = nil
+		g.applyTurn(player, &Move{Player: player.Name, Type: TurnPass, RackBefore: slices.Clone(player.Letters)})
+	case PlayWithdrawn:
+		return g.applyPlayWithdrawn(e)
+	case ChallengeFailed:
+		return g.applyChallengeFailed(e)
+	case ScoreAdjusted:
+		player, err := g.getPlayerByName(e.Player)
+		if err != nil {
+			return err
+		}
+		player.Score += e.Points
+	case TurnUndone:
+		return g.undo()
+	case TurnRedone:
+		return g.redo()
+	case GameRestored:
+		if e.Classic == nil {
+			return fmt.Errorf("no classic game snapshot to restore")
+		}
+		return g.restoreSnapshot(e.Classic)
+	case GameEnded:
+		if g.Complete {
+			return fmt.Errorf("game is complete")
+		}
+		g.finish(e.Reason)
+	default:
+		return fmt.Errorf("%s events do not apply to classic games", e.EventType())
+	}
+	return nil
+}
+
+// reset clears the game back to an empty board and a full bag.
+func (g *Classic) reset(rule ChallengeRule) {
+	g.Board = NewBoard(StandardBoardSize)
+	g.Players = make([]*Player, 0)
+	g.CurrentPlayer = 0
+	if g.SpareLetters == nil {
+		g.SpareLetters = NewTileBag(nil)
+	}
+	g.SpareLetters.Fill(makeLetterBag())
+	g.NumWordsPlaced = 0
+	g.Complete = false
+	g.EndReason = EndNotFinished
+	g.History = make([]*Move, 0)
+	g.ScorelessTurns = 0
+	g.ChallengeRule = rule
+	g.lastPlay = nil
+	g.undoStates = nil
+	g.redoStates = nil
+}
+
+// turnPlayer returns the current player, failing if they are not the named player.
+func (g *Classic) turnPlayer(name string) (*Player, error) {
+	if g.Complete {
+		return nil, fmt.Errorf("game is complete")
+	}
+	player, err := g.GetCurrentPlayer()
+	if err != nil {
+		return nil, err
+	}
+	if player.Name != name {
+		return nil, fmt.Errorf("it is not %s's turn", name)
+	}
+	return player, nil
+}
+
+// checkWordPlaced returns an error if the event cannot be applied. It is also used to check a move before any tiles
+// are drawn for it, so that a rejected move does not use up a random draw.
+func (g *Classic) checkWordPlaced(e WordPlaced) (*Player, *PlacementResult, error) {
+	player, err := g.turnPlayer(e.Player)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := g.Board.isValidWordPlacement(e.Placement, e.Word, g.NumWordsPlaced == 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	if result.Score() != e.Score {
+		return nil, nil, fmt.Errorf("%s scores %d not %d", e.Word, result.Score(), e.Score)
+	}
+	if !player.hasLetters(result.LettersSpent) {
+		return nil, nil, fmt.Errorf("player does not have all letters of word: %s", e.Word)
+	}
+	if !g.SpareLetters.contains([]rune(e.Drawn)) {
+		return nil, nil, fmt.Errorf("bag does not contain all of: %s", e.Drawn)
+	}
+	return player, result, nil
+}
+
+func (g *Classic) applyWordPlaced(e WordPlaced) error {
+	player, result, err := g.checkWordPlaced(e)
+	if err != nil {
+		return err
+	}
+	drawn := []rune(e.Drawn)
+
+	before := g.snapshot()
+	g.saveUndoState(before)
+	rack := slices.Clone(player.Letters)
+
+	// spend the letters
+	if err := player.removeLetters(result.LettersSpent); err != nil {
+		return err
+	}
+
+	// update the board
+	if _, err := g.Board.placeWord(e.Placement, e.Word); err != nil {
+		return err
+	}
+
+	if err := g.SpareLetters.Take(drawn...); err != nil {
+		return err
+	}
+	player.Letters = append(player.Letters, drawn...)
+
+	// scoring
+	player.Score += result.Score()
+
+	g.NumWordsPlaced++
+
+	g.lastPlay = &lastPlay{before: before, result: result}
+
+	g.applyTurn(player, &Move{
+		Player:      player.Name,
+		Type:        TurnPlay,
+		Placement:   e.Placement,
+		Word:        e.Word,
+		Score:       result.Score(),
+		Explanation: result.ExplainScore(),
+		Drawn:       drawn,
+		RackBefore:  rack,
+	})
+	return nil
+}
+
+// checkTilesExchanged returns an error if the event cannot be applied, see checkWordPlaced.
+func (g *Classic) checkTilesExchanged(e TilesExchanged) (*Player, error) {
+	player, err := g.turnPlayer(e.Player)
+	if err != nil {
+		return nil, err
+	}
+	if !player.hasLetters([]rune(e.Tiles)) {
+		return nil, fmt.Errorf("player does not have all letters to exchange: %s", e.Tiles)
+	}
+	if !g.SpareLetters.contains([]rune(e.Drawn)) {
+		return nil, fmt.Errorf("bag does not contain all of: %s", e.Drawn)
+	}
+	return player, nil
+}
+
+func (g *Classic) applyTilesExchanged(e TilesExchanged) error {
+	player, err := g.checkTilesExchanged(e)
+	if err != nil {
+		return err
+	}
+	letters, drawn := []rune(e.Tiles), []rune(e.Drawn)
+
+	g.saveUndoState(g.snapshot())
+	g.lastPlay = nil
+	rack := slices.Clone(player.Letters)
+
+	if err := player.removeLetters(letters); err != nil {
+		return err
+	}
+	if err := g.SpareLetters.Take(drawn...); err != nil {
+		return err
+	}
+	player.Letters = append(player.Letters, drawn...)
+	g.SpareLetters.Return(letters...)
+
+	g.applyTurn(player, &Move{
+		Player:     player.Name,
+		Type:       TurnExchange,
+		Exchanged:  letters,
+		Drawn:      drawn,
+		RackBefore: rack,
+	})
+	return nil
+}
+
+// applyTurn adds a finished turn to the history and moves on to the next player unless the game is over.
+func (g *Classic) applyTurn(player *Player, move *Move) {
+	move.RackAfter = slices.Clone(player.Letters)
+	g.History = append(g.History, move)
+
+	if move.Score == 0 {
+		g.ScorelessTurns++
+	} else {
+		g.ScorelessTurns = 0
+	}
+
+	if g.gameOverReason() == EndNotFinished {
+		g.NextPlayer()
+	}
+}
+
+// Events returns every event recorded by the game so far.
+func (s *Scrabulous) Events() []Event {
+	return slices.Clone(s.events)
+}
+
+// Replay resets the game and applies the first upTo events, or all of them if upTo is negative. The game's
+// options such as the lexicon are kept.
+func (s *Scrabulous) Replay(events []Event, upTo int) error {
+	if upTo < 0 || upTo > len(events) {
+		upTo = len(events)
+	}
+	s.reset(s.StealTime)
+	s.events = nil
+	for i, e := range events[:upTo] {
+		if err := s.apply(e); err != nil {
+			return fmt.Errorf("failed to replay event %d (%s): %w", i, e.EventType(), err)
+		}
+		s.events = append(s.events, e)
+	}
+	return nil
+}
+
+// record applies the event, adds it to the game's events and tells any subscribers.
+func (s *Scrabulous) record(e Event) error {
+	before := s.observe()
+	if err := s.apply(e); err != nil {
+		return err
+	}
+	s.events = append(s.events, e)
+	s.subscribers.publish(append([]Event{e}, s.notifications(before, e)...)...)
+	return nil
+}
+
+func (s *Scrabulous) apply(e Event) error {
+	switch e := e.(type) {
+	case GameStarted:
+		s.reset(e.StealTime)
+	case TilesDrawn:
+		if err := s.SpareLetters.Take([]rune(e.Tiles)...); err != nil {
+			return err
+		}
+		s.Letters = append(s.Letters, []rune(e.Tiles)...)
+	case TilesReturned:
+		if err := s.removeLetters([]rune(e.Tiles)); err != nil {
+			return err
+		}
+		s.SpareLetters.Return([]rune(e.Tiles)...)
+	case WordProposed:
+		if len(s.PendingWords) > 0 {
+			return fmt.Errorf("a word has already been proposed")
+		}
+		return s.addPendingWord(e.Player, e.Placement, e.Word, e.At, false)
+	case WordStolen:
+		best := s.BestPendingWord()
+		if best == nil || best.Submitter != e.From {
+			return fmt.Errorf("there is no word by %s to steal", e.From)
+		}
+		return s.addPendingWord(e.Player, e.Placement, e.Word, e.At, true)
+	case WordPlaced:
+		return s.applyWordPlaced(e)
+	case GameRestored:
+		if e.Scrabulous == nil {
+			return fmt.Errorf("no scrabulous game snapshot to restore")
+		}
+		return s.restoreSnapshot(e.Scrabulous)
+	case GameEnded:
+		s.Complete = true
+	default:
+		return fmt.Errorf("%s events do not apply to scrabulous games", e.EventType())
+	}
+	return nil
+}
+
+// reset clears the game back to an empty board and a full bag.
+func (s *Scrabulous) reset(stealTime time.Duration) {
+	s.Board = NewBoard(StandardBoardSize)
+	if s.SpareLetters == nil {
+		s.SpareLetters = NewTileBag(nil)
+	}
+	s.SpareLetters.Fill(makeLetterBag())
+	s.Letters = nil
+	s.PlacedWords = make([]*Word, 0)
+	s.PendingWords = make([]*Word, 0)
+	s.PlaceWordAt = nil
+	s.GameState = StateIdle
+	s.Complete = false
+	s.StealTime = stealTime
+}
+
+func (s *Scrabulous) addPendingWord(player string, place Placement, word string, at time.Time, stolen bool) error {
+	result, err := s.Board.isValidWordPlacement(place, word, len(s.PlacedWords) == 0)
+	if err != nil {
+		return err
+	}
+	if !s.haveLetters(result.LettersSpent) {
+		return fmt.Errorf("you do not have all letters of word: %s", word)
+	}
+	if !s.IsNewBestWord(result.Score()) {
+		return fmt.Errorf("%s does not score more than the best pending word", word)
+	}
+	if !stolen {
+		s.startStealTime(at)
+	}
+	s.PendingWords = append(s.PendingWords, &Word{
+		Word:      []rune(word),
+		Submitter: player,
+		Place:     place,
+		Result:    result,
+		Stolen:    stolen,
+	})
+	return nil
+}
+
+func (s *Scrabulous) applyWordPlaced(e WordPlaced) error {
+	best := s.bestPendingWord()
+	if best == nil || best.Submitter != e.Player || best.Place != e.Placement || string(best.Word) != e.Word {
+		return fmt.Errorf("%s by %s is not the best pending word", e.Word, e.Player)
+	}
+	result, err := s.Board.placeWord(best.Place, string(best.Word))
+	if err != nil {
+		return err
+	}
+
+	s.PlacedWords = append(s.PlacedWords, best)
+
+	if err := s.removeLetters(result.LettersSpent); err != nil {
+		return err
+	}
+
+	s.setGameIdle()
+	return nil
+}
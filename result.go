@@ -0,0 +1,114 @@
+package scrabble
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MaxScorelessTurns is the number of consecutive scoreless turns (passes, exchanges or zero scoring plays) after
+// which the game ends.
+const MaxScorelessTurns = 6
+
+type EndReason string
+
+const (
+	EndNotFinished    EndReason = ""
+	EndPlayerWentOut  EndReason = "player_went_out"
+	EndScorelessTurns EndReason = "scoreless_turns"
+	// EndOutOfTiles ends a Scrabulous game when the shared rack and the bag are empty.
+	EndOutOfTiles EndReason = "out_of_tiles"
+)
+
+type Standing struct {
+	Player string `json:"player"`
+	Score  int    `json:"score"`
+	// RackValue is the value of the tiles left on the player's rack at the end of the game.
+	RackValue int `json:"rack_value,omitempty"`
+	// Adjustment is the change made to the player's score when the game ended.
+	Adjustment int `json:"adjustment,omitempty"`
+}
+
+type GameResult struct {
+	Reason EndReason `json:"reason"`
+	// Standings are ordered by score, highest first.
+	Standings []Standing `json:"standings"`
+	// Winner is the name of the highest scoring player or empty if the game was tied.
+	Winner string `json:"winner,omitempty"`
+	Tied   bool   `json:"tied,omitempty"`
+}
+
+// Result returns the final standings of a complete game.
+func (g *Classic) Result() (*GameResult, error) {
+	if !g.Complete {
+		return nil, fmt.Errorf("game is not complete")
+	}
+	result := &GameResult{Reason: g.EndReason, Standings: make([]Standing, 0, len(g.Players))}
+	for _, p := range g.Players {
+		result.Standings = append(result.Standings, Standing{
+			Player:     p.Name,
+			Score:      p.Score,
+			RackValue:  rackValue(p.Letters),
+			Adjustment: g.endAdjustment(p, g.EndReason),
+		})
+	}
+	result.rank()
+	return result, nil
+}
+
+// Result returns the final standings of a complete Scrabulous game. Only players who placed a word are included.
+func (s *Scrabulous) Result() (*GameResult, error) {
+	if !s.Complete {
+		return nil, fmt.Errorf("game is not complete")
+	}
+	result := &GameResult{Reason: EndOutOfTiles, Standings: make([]Standing, 0)}
+	for _, score := range s.GetScores() {
+		result.Standings = append(result.Standings, Standing{Player: score.PlayerName, Score: score.Score})
+	}
+	result.rank()
+	return result, nil
+}
+
+// rank orders the standings and decides the winner.
+func (r *GameResult) rank() {
+	slices.SortStableFunc(r.Standings, func(a, b Standing) int {
+		return b.Score - a.Score
+	})
+	if len(r.Standings) > 1 && r.Standings[0].Score == r.Standings[1].Score {
+		r.Tied = true
+	} else if len(r.Standings) > 0 {
+		r.Winner = r.Standings[0].Player
+	}
+}
+
+// finish ends the game. If a player went out they gain the value of everyone else's remaining tiles, otherwise
+// every player loses the value of their own tiles.
+func (g *Classic) finish(reason EndReason) {
+	adjustments := make([]int, len(g.Players))
+	for i, p := range g.Players {
+		adjustments[i] = g.endAdjustment(p, reason)
+	}
+	for i, p := range g.Players {
+		p.Score += adjustments[i]
+	}
+	g.EndReason = reason
+	g.Complete = true
+}
+
+func (g *Classic) endAdjustment(player *Player, reason EndReason) int {
+	if reason == EndPlayerWentOut && len(player.Letters) == 0 {
+		total := 0
+		for _, p := range g.Players {
+			total += rackValue(p.Letters)
+		}
+		return total
+	}
+	return -rackValue(player.Letters)
+}
+
+func rackValue(letters []rune) int {
+	total := 0
+	for _, l := range letters {
+		total += LetterScores[l]
+	}
+	return total
+}
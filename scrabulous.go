@@ -2,9 +2,7 @@ package scrabble
 
 import (
 	"fmt"
-	"math/rand/v2"
 	"slices"
-	"strings"
 	"time"
 )
 
@@ -34,9 +32,13 @@ type Score struct {
 	Words      int
 }
 
-func NewScrabulousGame(stealTime time.Duration) *Scrabulous {
+func NewScrabulousGame(stealTime time.Duration, opts ...GameOption) *Scrabulous {
+	options := resolveGameOptions(opts...)
 	game := &Scrabulous{
-		StealTime: stealTime,
+		StealTime:    stealTime,
+		lexicon:      options.lexicon,
+		SpareLetters: NewTileBag(options.randSource),
+		clock:        options.clock,
 	}
 	game.ResetGame()
 
@@ -45,7 +47,7 @@ func NewScrabulousGame(stealTime time.Duration) *Scrabulous {
 
 type Scrabulous struct {
 	Board        Board
-	SpareLetters []rune
+	SpareLetters *TileBag
 	Letters      []rune
 	PlacedWords  []*Word
 	PendingWords []*Word
@@ -53,6 +55,11 @@ type Scrabulous struct {
 	Complete     bool
 	GameState    ScrabulousState
 	StealTime    time.Duration
+
+	lexicon     Lexicon
+	clock       Clock
+	events      []Event
+	subscribers subscribers
 }
 
 func (s *Scrabulous) IsPlayerAllowed(playerName string) bool {
@@ -77,84 +84,93 @@ func (s *Scrabulous) BestPendingWord() *Word {
 }
 
 func (s *Scrabulous) TryPlacePendingWord() error {
-	if len(s.PendingWords) > 0 && s.PlaceWordAt != nil && time.Now().After(*s.PlaceWordAt) {
+	if len(s.PendingWords) > 0 && s.PlaceWordAt != nil && !s.getClock().Now().Before(*s.PlaceWordAt) {
 		return s.PlacePendingWord()
 	}
 	return nil
 }
 
-func (s *Scrabulous) CreatePendingWord(place Placement, word string, playerName string) (*PlacementResult, error) {
-	word = strings.ToUpper(word)
+// StealTimeRemaining returns how long is left before the best pending word is placed, or zero if the steal window
+// is not open.
+func (s *Scrabulous) StealTimeRemaining() time.Duration {
+	if s.GameState != StateStealing || s.PlaceWordAt == nil {
+		return 0
+	}
+	return max(s.PlaceWordAt.Sub(s.getClock().Now()), 0)
+}
 
+func (s *Scrabulous) CreatePendingWord(place Placement, word string, playerName string) (*PlacementResult, error) {
 	// is the word valid
 	result, err := s.Board.isValidWordPlacement(place, word, len(s.PlacedWords) == 0)
 	if err != nil {
 		return nil, err
 	}
+	if err := validateWords(s.lexicon, result); err != nil {
+		return nil, err
+	}
 
 	// do they have the letters required to make the word considering overlaps
 	if !s.haveLetters(result.LettersSpent) {
 		return nil, fmt.Errorf("you do not have all letters of word: %s", word)
 	}
 
-	// if this is the beginning of a new
-	firstPendingWord := false
-	if len(s.PendingWords) == 0 {
-		s.startStealTime()
-		firstPendingWord = true
+	if !s.IsNewBestWord(result.Score()) {
+		return nil, nil
 	}
 
-	// if it's a new best word add it to the end fo the list
-	if s.IsNewBestWord(result.Score()) {
-		s.PendingWords = append(
-			s.PendingWords,
-			&Word{
-				Word:      []rune(word),
-				Submitter: playerName,
-				Place:     place,
-				Result:    result,
-				Stolen:    !firstPendingWord,
-			},
-		)
-		return result, nil
+	// the first word starts the steal window, any better words after that are steals
+	now := s.getClock().Now()
+	var proposal Event = WordProposed{Player: playerName, Placement: place, Word: word, Score: result.Score(), At: now}
+	if best := s.BestPendingWord(); best != nil {
+		proposal = WordStolen{
+			Player:    playerName,
+			From:      best.Submitter,
+			Placement: place,
+			Word:      word,
+			Score:     result.Score(),
+			At:        now,
+		}
 	}
-
-	return nil, nil
+	if err := s.record(proposal); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 func (s *Scrabulous) PlacePendingWord() error {
-	var best *Word
-	for _, v := range s.PendingWords {
-		if best == nil || v.Result.Score() > best.Result.Score() {
-			best = v
-		}
-	}
+	best := s.bestPendingWord()
 	if best == nil {
 		return fmt.Errorf("no pending words")
 	}
-
-	result, err := s.Board.placeWord(best.Place, string(best.Word))
-	if err != nil {
+	if err := s.record(WordPlaced{
+		Player:      best.Submitter,
+		Placement:   best.Place,
+		Word:        string(best.Word),
+		Score:       best.Result.Score(),
+		Explanation: best.Result.ExplainScore(),
+	}); err != nil {
 		return err
 	}
 
-	s.PlacedWords = append(s.PlacedWords, best)
-
-	if err := s.removeLetters(result.LettersSpent); err != nil {
-		fmt.Printf("Failed to remove letters, this is likely a bug: %s", err.Error())
-	}
-
 	s.ResetLetters()
 
-	if len(s.Letters) == 0 && len(s.SpareLetters) == 0 {
-		s.Complete = true
+	if len(s.Letters) == 0 && s.SpareLetters.Len() == 0 {
+		return s.record(GameEnded{Reason: EndOutOfTiles})
 	}
-
-	s.setGameIdle()
-
 	return nil
 }
 
+// bestPendingWord returns the highest scoring pending word, the earliest word wins a tie.
+func (s *Scrabulous) bestPendingWord() *Word {
+	var best *Word
+	for _, v := range s.PendingWords {
+		if best == nil || v.Result.Score() > best.Result.Score() {
+			best = v
+		}
+	}
+	return best
+}
+
 func (s *Scrabulous) GetScores() []*Score {
 	scores := make([]*Score, 0)
 	for _, v := range s.PlacedWords {
@@ -190,40 +206,35 @@ func (s *Scrabulous) setGameIdle() {
 	s.PlaceWordAt = nil
 }
 
-func (s *Scrabulous) startStealTime() {
-	finishAt := time.Now().Add(s.StealTime)
+func (s *Scrabulous) startStealTime(at time.Time) {
+	finishAt := at.Add(s.StealTime)
 	s.PlaceWordAt = &finishAt
 	s.GameState = StateStealing
 }
 
+// getClock returns the game's clock, games that were not created by NewScrabulousGame use the system clock.
+func (s *Scrabulous) getClock() Clock {
+	if s.clock == nil {
+		return systemClock{}
+	}
+	return s.clock
+}
+
 func (s *Scrabulous) haveLetters(letters []rune) bool {
-	_, foundAll := s.getUsedLetters(letters)
+	_, foundAll := takeLetters(s.Letters, letters)
 	return foundAll
 }
 
 func (s *Scrabulous) ResetLetters() {
+	// recording these events cannot fail as the letters are always on the rack or in the bag
+
 	// return any letters to pool
-	for _, v := range s.Letters {
-		s.SpareLetters = append(s.SpareLetters, v)
+	if len(s.Letters) > 0 {
+		_ = s.record(TilesReturned{Tiles: string(s.Letters)})
 	}
 
-	// reset
-	s.Letters = make([]rune, 0)
-
 	// add new ones from the pool
-	for {
-		if len(s.Letters)+1 > NumPlayerLetters || len(s.SpareLetters) == 0 {
-			return
-		}
-		var letterIdx int
-		if len(s.SpareLetters)-1 == 0 {
-			letterIdx = 0
-		} else {
-			letterIdx = rand.IntN(len(s.SpareLetters) - 1)
-		}
-		s.Letters = append(s.Letters, s.SpareLetters[letterIdx])
-		s.SpareLetters = slices.Delete(s.SpareLetters, letterIdx, letterIdx+1)
-	}
+	_ = s.record(TilesDrawn{Tiles: string(s.SpareLetters.pick(NumPlayerLetters))})
 }
 
 func (s *Scrabulous) GetLastPendingWord() *Word {
@@ -234,59 +245,16 @@ func (s *Scrabulous) GetLastPendingWord() *Word {
 }
 
 func (s *Scrabulous) ResetGame() {
-	s.Board = NewBoard(15)
-	s.SpareLetters = makeLetterBag()
-	s.PlacedWords = make([]*Word, 0)
-	s.PendingWords = make([]*Word, 0)
-	s.PlaceWordAt = nil
-	s.GameState = StateIdle
-	s.Complete = false
+	// starting a game cannot fail
+	_ = s.record(GameStarted{StealTime: s.StealTime})
 	s.ResetLetters()
 }
 
 func (s *Scrabulous) removeLetters(letters []rune) error {
-	newLetters := make([]rune, 0, 7)
-	usageMap, foundAll := s.getUsedLetters(letters)
+	remaining, foundAll := takeLetters(s.Letters, letters)
 	if !foundAll {
 		return fmt.Errorf("all letters were not found to be removed")
 	}
-	for letter, avail := range usageMap {
-		for range avail {
-			newLetters = append(newLetters, letter)
-		}
-	}
-	s.Letters = newLetters
+	s.Letters = remaining
 	return nil
 }
-
-func (s *Scrabulous) getUsedLetters(word []rune) (map[rune]int, bool) {
-	lettermap := map[rune]int{}
-
-	foundAll := true
-	for _, l := range s.Letters {
-		if _, ok := lettermap[l]; ok {
-			lettermap[l]++
-		} else {
-			lettermap[l] = 1
-		}
-	}
-	for _, want := range word {
-		found := false
-		if avail, ok := lettermap[want]; ok && avail > 0 {
-			found = true
-			lettermap[want]--
-		}
-		if !found {
-			// if they have a blank they can use that instead for any letter
-			if avail, ok := lettermap['_']; ok && avail > 0 {
-				found = true
-				lettermap['_']--
-			}
-		}
-		if !found {
-			foundAll = false
-		}
-	}
-
-	return lettermap, foundAll
-}
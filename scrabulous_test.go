@@ -0,0 +1,150 @@
+package scrabble
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestScrabulousGame(t *testing.T, clock *FakeClock, letters string) *Scrabulous {
+	t.Helper()
+	game := NewScrabulousGame(time.Minute, WithClock(clock), WithSeed(1))
+	game.Letters = []rune(letters)
+	return game
+}
+
+func TestScrabulous_stealWindow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	centre := Placement{CellId: 112, Direction: Across}
+
+	type submission struct {
+		after  time.Duration
+		player string
+		word   string
+	}
+	tests := []struct {
+		name        string
+		submissions []submission
+		advance     time.Duration
+		wantPlaced  string
+		wantPending int
+	}{
+		{
+			name:        "word is pending until the window closes",
+			submissions: []submission{{player: "alice", word: "CAT"}},
+			advance:     time.Minute - time.Second,
+			wantPending: 1,
+		},
+		{
+			name:        "word is placed at the deadline",
+			submissions: []submission{{player: "alice", word: "CAT"}},
+			advance:     time.Minute,
+			wantPlaced:  "alice",
+		},
+		{
+			name:        "word is placed after the window closes",
+			submissions: []submission{{player: "alice", word: "CAT"}},
+			advance:     time.Minute + time.Second,
+			wantPlaced:  "alice",
+		},
+		{
+			name: "higher scoring word steals without extending the window",
+			submissions: []submission{
+				{player: "alice", word: "CAT"},
+				{after: time.Second * 30, player: "bob", word: "CATS"},
+			},
+			advance:    time.Second * 31,
+			wantPlaced: "bob",
+		},
+		{
+			name: "tied word does not steal",
+			submissions: []submission{
+				{player: "alice", word: "CAT"},
+				{after: time.Second, player: "bob", word: "ACT"},
+			},
+			advance:    time.Minute,
+			wantPlaced: "alice",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := NewFakeClock(start)
+			game := newTestScrabulousGame(t, clock, "CATSXYZ")
+			for _, s := range tt.submissions {
+				clock.Advance(s.after)
+				if _, err := game.CreatePendingWord(centre, s.word, s.player); err != nil {
+					t.Fatalf("CreatePendingWord() error = %v", err)
+				}
+			}
+			if want := start.Add(time.Minute); game.PlaceWordAt == nil || !game.PlaceWordAt.Equal(want) {
+				t.Fatalf("PlaceWordAt = %v, want %v", game.PlaceWordAt, want)
+			}
+
+			clock.Advance(tt.advance)
+			if err := game.TryPlacePendingWord(); err != nil {
+				t.Fatalf("TryPlacePendingWord() error = %v", err)
+			}
+			if got := len(game.PendingWords); got != tt.wantPending {
+				t.Errorf("got %d pending words, want %d", got, tt.wantPending)
+			}
+			if tt.wantPlaced == "" {
+				if len(game.PlacedWords) != 0 || game.GameState != StateStealing {
+					t.Errorf("word placed before the steal window closed")
+				}
+				return
+			}
+			if len(game.PlacedWords) != 1 || game.PlacedWords[0].Submitter != tt.wantPlaced {
+				t.Fatalf("placed words = %v, want one by %s", game.PlacedWords, tt.wantPlaced)
+			}
+			if game.GameState != StateIdle || game.PlaceWordAt != nil {
+				t.Errorf("game is still stealing after the word was placed")
+			}
+		})
+	}
+}
+
+func TestScrabulous_Subscribe(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	game := newTestScrabulousGame(t, clock, "CATSXYZ")
+
+	got := make([]Event, 0)
+	game.Subscribe(func(e Event) {
+		got = append(got, e)
+	})
+	centre := Placement{CellId: 112, Direction: Across}
+	if _, err := game.CreatePendingWord(centre, "CAT", "alice"); err != nil {
+		t.Fatalf("CreatePendingWord() error = %v", err)
+	}
+	if _, err := game.CreatePendingWord(centre, "CATS", "bob"); err != nil {
+		t.Fatalf("CreatePendingWord() error = %v", err)
+	}
+	if err := game.PlacePendingWord(); err != nil {
+		t.Fatalf("PlacePendingWord() error = %v", err)
+	}
+
+	types := make([]EventType, 0, len(got))
+	for _, e := range got {
+		types = append(types, e.EventType())
+	}
+	want := []EventType{
+		EventWordProposed,
+		EventStealWindowOpened,
+		EventWordStolen,
+		EventWordPlaced,
+		EventStealWindowClosed,
+		EventTilesReturned,
+		EventTilesDrawn,
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Fatalf("got events %v, want %v", types, want)
+	}
+	if opened := got[1].(StealWindowOpened); opened.Player != "alice" || !opened.ClosesAt.Equal(clock.Now().Add(time.Minute)) {
+		t.Errorf("unexpected steal window: %+v", opened)
+	}
+	if stolen := got[2].(WordStolen); stolen.From != "alice" || stolen.Score != 12 {
+		t.Errorf("unexpected steal: %+v", stolen)
+	}
+	if closed := got[4].(StealWindowClosed); closed.Player != "bob" || closed.Word != "CATS" {
+		t.Errorf("unexpected steal window close: %+v", closed)
+	}
+}
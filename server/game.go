@@ -0,0 +1,297 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/warmans/go-scrabble"
+)
+
+// DefaultStealTime is used for Scrabulous games created without a steal time.
+const DefaultStealTime = 30 * time.Second
+
+// liveGame is a game that has been loaded into memory.
+type liveGame struct {
+	// saveMu is held while a record of the game is taken and stored so that saves cannot overtake each other.
+	saveMu sync.Mutex
+	// mu guards everything below. Scrabulous games are also guarded by their own lock which must only be taken
+	// after mu.
+	mu         sync.Mutex
+	id         string
+	mode       Mode
+	tokens     map[string]string
+	classic    *scrabble.Classic
+	scrabulous *scrabble.ConcurrentScrabulous
+
+	// watchMu guards watchers, it is separate from mu as Scrabulous events are sent without holding mu.
+	watchMu  sync.Mutex
+	watchers map[*watcher]struct{}
+}
+
+// watcher queues a game's events for a live connection.
+type watcher struct {
+	mu     sync.Mutex
+	queue  []scrabble.Event
+	notify chan struct{}
+}
+
+// drain returns the queued events.
+func (w *watcher) drain() []scrabble.Event {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	events := w.queue
+	w.queue = nil
+	return events
+}
+
+func (g *liveGame) watch() (w *watcher, stop func()) {
+	w = &watcher{notify: make(chan struct{}, 1)}
+	g.watchMu.Lock()
+	defer g.watchMu.Unlock()
+	if g.watchers == nil {
+		g.watchers = make(map[*watcher]struct{})
+	}
+	g.watchers[w] = struct{}{}
+	return w, func() {
+		g.watchMu.Lock()
+		defer g.watchMu.Unlock()
+		delete(g.watchers, w)
+	}
+}
+
+// broadcast queues the event for every watcher without waiting for them.
+func (g *liveGame) broadcast(e scrabble.Event) {
+	g.watchMu.Lock()
+	defer g.watchMu.Unlock()
+	for w := range g.watchers {
+		w.mu.Lock()
+		w.queue = append(w.queue, e)
+		w.mu.Unlock()
+		select {
+		case w.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// player returns the name of the player with the token, tokens are optional so an empty token is a spectator.
+func (g *liveGame) player(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	name, ok := g.tokens[token]
+	if !ok {
+		return "", errUnauthorized
+	}
+	return name, nil
+}
+
+func (g *liveGame) hasPlayer(name string) bool {
+	for _, v := range g.tokens {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// events returns the game's recorded events encoded for storage.
+func (g *liveGame) events() ([]json.RawMessage, error) {
+	var events []scrabble.Event
+	if g.classic != nil {
+		events = g.classic.Events()
+	} else {
+		if err := g.scrabulous.Do(func(game *scrabble.Scrabulous) error {
+			events = game.Events()
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	encoded := make([]json.RawMessage, 0, len(events))
+	for _, e := range events {
+		data, err := scrabble.MarshalEvent(e)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, data)
+	}
+	return encoded, nil
+}
+
+func (g *liveGame) record() (*GameRecord, error) {
+	events, err := g.events()
+	if err != nil {
+		return nil, err
+	}
+	record := &GameRecord{ID: g.id, Mode: g.mode, Tokens: make(map[string]string, len(g.tokens)), Events: events}
+	for k, v := range g.tokens {
+		record.Tokens[k] = v
+	}
+	return record, nil
+}
+
+// GameView is the state of a game as seen by one player. Other players' tiles and the contents of the bag are
+// hidden: each hidden tile is shown as '?'.
+type GameView struct {
+	ID   string `json:"id"`
+	Mode Mode   `json:"mode"`
+	// You is the name of the player the view was made for, it is empty for spectators.
+	You        string                       `json:"you,omitempty"`
+	Classic    *scrabble.ClassicSnapshot    `json:"classic,omitempty"`
+	Scrabulous *scrabble.ScrabulousSnapshot `json:"scrabulous,omitempty"`
+}
+
+// view must be called while holding the game lock.
+func (g *liveGame) view(player string) (*GameView, error) {
+	view := &GameView{ID: g.id, Mode: g.mode, You: player}
+	if g.classic != nil {
+		view.Classic = redactClassic(g.classic.Snapshot(), player)
+		return view, nil
+	}
+	err := g.scrabulous.Do(func(game *scrabble.Scrabulous) error {
+		view.Scrabulous = game.Snapshot()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	view.Scrabulous.Bag = hideTiles(view.Scrabulous.Bag)
+	if player == "" {
+		// the rack is shared by all players but not spectators
+		view.Scrabulous.Letters = hideTiles(view.Scrabulous.Letters)
+	}
+	return view, nil
+}
+
+func redactClassic(snap *scrabble.ClassicSnapshot, player string) *scrabble.ClassicSnapshot {
+	snap.Bag = hideTiles(snap.Bag)
+	if snap.Complete {
+		// the final racks are revealed by the end of game scoring anyway
+		return snap
+	}
+	for i, p := range snap.Players {
+		if p.Name != player {
+			snap.Players[i].Letters = hideTiles(p.Letters)
+		}
+	}
+	for i, m := range snap.History {
+		if m.Player != player {
+			snap.History[i].Rack = hideTiles(m.Rack)
+			snap.History[i].RackAfter = hideTiles(m.RackAfter)
+			snap.History[i].Drawn = hideTiles(m.Drawn)
+			snap.History[i].Exchanged = hideTiles(m.Exchanged)
+		}
+	}
+	return snap
+}
+
+func hideTiles(tiles string) string {
+	return strings.Repeat("?", len([]rune(tiles)))
+}
+
+// newGame starts a new game.
+func (s *Server) newGame(mode Mode, ruleName scrabble.ChallengeRule, stealTime time.Duration) (*liveGame, error) {
+	g := &liveGame{id: newID(), mode: mode, tokens: make(map[string]string)}
+	switch mode {
+	case ModeClassic:
+		rule, err := scrabble.ParseChallengeRule(string(ruleName))
+		if err != nil {
+			return nil, err
+		}
+		g.classic = scrabble.NewClassicGame(append(slices.Clone(s.gameOpts), scrabble.WithChallengeRule(rule))...)
+		g.classic.Subscribe(g.broadcast)
+	case ModeScrabulous:
+		if stealTime <= 0 {
+			stealTime = DefaultStealTime
+		}
+		g.scrabulous = s.newScrabulous(g, stealTime)
+	default:
+		return nil, fmt.Errorf("unknown game mode: %s", mode)
+	}
+	return g, nil
+}
+
+// loadGame rebuilds a game from its record.
+func (s *Server) loadGame(record *GameRecord) (*liveGame, error) {
+	events := make([]scrabble.Event, 0, len(record.Events))
+	for i, data := range record.Events {
+		e, err := scrabble.UnmarshalEvent(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event %d: %w", i, err)
+		}
+		events = append(events, e)
+	}
+	g := &liveGame{id: record.ID, mode: record.Mode, tokens: make(map[string]string, len(record.Tokens))}
+	for k, v := range record.Tokens {
+		g.tokens[k] = v
+	}
+
+	switch record.Mode {
+	case ModeClassic:
+		g.classic = scrabble.NewClassicGame(s.gameOpts...)
+		if err := g.classic.Replay(events, -1); err != nil {
+			return nil, err
+		}
+		g.classic.Subscribe(g.broadcast)
+	case ModeScrabulous:
+		// the steal time is restored by the events
+		g.scrabulous = s.newScrabulous(g, DefaultStealTime)
+		if err := g.scrabulous.Do(func(game *scrabble.Scrabulous) error {
+			return game.Replay(events, -1)
+		}); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown game mode: %s", record.Mode)
+	}
+	return g, nil
+}
+
+func (s *Server) newScrabulous(g *liveGame, stealTime time.Duration) *scrabble.ConcurrentScrabulous {
+	opts := append(slices.Clone(s.gameOpts), scrabble.WithEventHandler(func(e scrabble.Event) {
+		// words placed when the steal window closes happen outside any request
+		if e.EventType() == scrabble.EventWordPlaced {
+			_ = s.save(s.ctx, g)
+		}
+		g.broadcast(e)
+	}))
+	return scrabble.NewConcurrentScrabulous(s.ctx, stealTime, opts...)
+}
+
+// save stores the current state of the game.
+func (s *Server) save(ctx context.Context, g *liveGame) error {
+	g.saveMu.Lock()
+	defer g.saveMu.Unlock()
+
+	g.mu.Lock()
+	record, err := g.record()
+	g.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return s.store.Save(ctx, record)
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func validID(id string) bool {
+	if len(id) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
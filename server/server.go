@@ -0,0 +1,488 @@
+// Package server hosts Classic and Scrabulous games over a JSON API.
+//
+// Players join a game to get a token which they send as a bearer token in the Authorization header. Requests
+// without a token are made as a spectator who can see the board but no tiles. Clients can connect to
+// /games/{id}/ws to be sent live updates over a WebSocket instead of polling.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/png"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/warmans/go-scrabble"
+)
+
+const (
+	defaultImageWidth  = 1500
+	defaultImageHeight = 1000
+	maxImageSize       = 4000
+)
+
+var (
+	errUnauthorized = errors.New("invalid token")
+	errNotYourTurn  = errors.New("it is not your turn")
+)
+
+type Option func(s *Server)
+
+// WithLexicon validates words using the lexicon. A GADDAG lexicon is required for bots.
+func WithLexicon(lexicon scrabble.Lexicon) Option {
+	return func(s *Server) {
+		s.gameOpts = append(s.gameOpts, scrabble.WithLexicon(lexicon))
+	}
+}
+
+// WithClock replaces the system clock used to time Scrabulous steal windows.
+func WithClock(clock scrabble.Clock) Option {
+	return func(s *Server) {
+		s.gameOpts = append(s.gameOpts, scrabble.WithClock(clock))
+	}
+}
+
+func New(store Store, opts ...Option) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		ctx:    ctx,
+		cancel: cancel,
+		store:  store,
+		games:  make(map[string]*liveGame),
+		mux:    http.NewServeMux(),
+
+		countdownInterval: defaultCountdownInterval,
+	}
+	for _, v := range opts {
+		v(s)
+	}
+	s.mux.HandleFunc("POST /games", s.handleCreate)
+	s.mux.HandleFunc("GET /games/{id}", s.handleView)
+	s.mux.HandleFunc("POST /games/{id}/players", s.handleJoin)
+	s.mux.HandleFunc("POST /games/{id}/place", s.handlePlace)
+	s.mux.HandleFunc("POST /games/{id}/exchange", s.handleExchange)
+	s.mux.HandleFunc("POST /games/{id}/pass", s.handlePass)
+	s.mux.HandleFunc("GET /games/{id}/board.png", s.handleImage)
+	s.mux.HandleFunc("GET /games/{id}/ws", s.handleWebSocket)
+	return s
+}
+
+type Server struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	store    Store
+	gameOpts []scrabble.GameOption
+
+	mu    sync.Mutex
+	games map[string]*liveGame
+
+	mux               *http.ServeMux
+	upgrader          websocket.Upgrader
+	countdownInterval time.Duration
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Close stops the steal timers of any Scrabulous games. Games are saved after every change so nothing is lost.
+func (s *Server) Close() {
+	s.cancel()
+}
+
+// game returns the game from memory, loading it from the store if needed.
+func (s *Server) game(ctx context.Context, id string) (*liveGame, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if g, ok := s.games[id]; ok {
+		return g, nil
+	}
+	record, err := s.store.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.loadGame(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game %s: %w", id, err)
+	}
+	s.games[id] = g
+	return g, nil
+}
+
+type createRequest struct {
+	Mode          Mode                   `json:"mode"`
+	ChallengeRule scrabble.ChallengeRule `json:"challenge_rule,omitempty"`
+	// StealTime is a duration such as "30s", it is only used by Scrabulous games.
+	StealTime string `json:"steal_time,omitempty"`
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	req := createRequest{}
+	if err := decodeRequest(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var stealTime time.Duration
+	if req.StealTime != "" {
+		var err error
+		if stealTime, err = time.ParseDuration(req.StealTime); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid steal time: %w", err))
+			return
+		}
+	}
+	g, err := s.newGame(req.Mode, req.ChallengeRule, stealTime)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := s.save(r.Context(), g); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.mu.Lock()
+	s.games[g.id] = g
+	s.mu.Unlock()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	s.writeView(w, http.StatusCreated, g, "")
+}
+
+func (s *Server) handleView(w http.ResponseWriter, r *http.Request) {
+	s.withGame(w, r, func(g *liveGame, player string) (int, error) {
+		return http.StatusOK, nil
+	})
+}
+
+type joinRequest struct {
+	Name string `json:"name"`
+	// Bot is the name of a bot strategy to add a computer player to a Classic game.
+	Bot string `json:"bot,omitempty"`
+}
+
+type joinResponse struct {
+	Name string `json:"name"`
+	// Token identifies the player in later requests. Bots do not have a token.
+	Token string `json:"token,omitempty"`
+}
+
+func (s *Server) handleJoin(w http.ResponseWriter, r *http.Request) {
+	req := joinRequest{}
+	if err := decodeRequest(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("name is required"))
+		return
+	}
+	g, err := s.game(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeGameError(w, err)
+		return
+	}
+
+	res, status, err := func() (*joinResponse, int, error) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if g.hasPlayer(req.Name) {
+			return nil, http.StatusConflict, fmt.Errorf("%s has already joined", req.Name)
+		}
+		if g.classic == nil {
+			if req.Bot != "" {
+				return nil, http.StatusBadRequest, fmt.Errorf("bots can only play classic games")
+			}
+			token := newID()
+			g.tokens[token] = req.Name
+			return &joinResponse{Name: req.Name, Token: token}, http.StatusCreated, nil
+		}
+		for _, p := range g.classic.Players {
+			if p.Name == req.Name {
+				return nil, http.StatusConflict, fmt.Errorf("%s has already joined", req.Name)
+			}
+		}
+		if req.Bot != "" {
+			strategy, err := scrabble.BotStrategyByName(req.Bot)
+			if err != nil {
+				return nil, http.StatusBadRequest, err
+			}
+			if err := g.classic.AddBot(req.Name, strategy); err != nil {
+				return nil, http.StatusBadRequest, err
+			}
+			return &joinResponse{Name: req.Name}, http.StatusCreated, nil
+		}
+		if err := g.classic.AddPlayer(req.Name); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		// a bot may have been waiting for someone to play against
+		if err := g.classic.PlayBotTurns(); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		token := newID()
+		g.tokens[token] = req.Name
+		return &joinResponse{Name: req.Name, Token: token}, http.StatusCreated, nil
+	}()
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	if err := s.save(r.Context(), g); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, status, res)
+}
+
+type placeRequest struct {
+	// Placement is a cell index and direction e.g. "A113" and Coordinate is standard notation e.g. "8H", only one
+	// of them may be given.
+	Placement  string `json:"placement,omitempty"`
+	Coordinate string `json:"coordinate,omitempty"`
+	// Word is played with a blank for each lower case letter, a word sent entirely in lower case has no blanks.
+	Word string `json:"word"`
+}
+
+func (r placeRequest) parsePlacement() (scrabble.Placement, error) {
+	switch {
+	case r.Placement != "" && r.Coordinate != "":
+		return scrabble.Placement{}, fmt.Errorf("only one of placement or coordinate may be given")
+	case r.Coordinate != "":
+		return scrabble.ParseCoordinate(r.Coordinate)
+	}
+	return scrabble.ParsePlacement(r.Placement)
+}
+
+func (r placeRequest) word() string {
+	if strings.ToLower(r.Word) == r.Word {
+		return strings.ToUpper(r.Word)
+	}
+	return r.Word
+}
+
+func (s *Server) handlePlace(w http.ResponseWriter, r *http.Request) {
+	req := placeRequest{}
+	if err := decodeRequest(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	place, err := req.parsePlacement()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	word := req.word()
+	s.withPlayerTurn(w, r, func(g *liveGame, player string) error {
+		if g.classic != nil {
+			return g.classic.PlaceWord(place, word)
+		}
+		return g.scrabulous.Do(func(game *scrabble.Scrabulous) error {
+			if !game.IsPlayerAllowed(player) {
+				return errNotYourTurn
+			}
+			result, err := game.CreatePendingWord(place, word, player)
+			if err != nil {
+				return err
+			}
+			if result == nil {
+				return fmt.Errorf("%s does not score more than the best pending word", word)
+			}
+			return nil
+		})
+	})
+}
+
+type exchangeRequest struct {
+	Tiles string `json:"tiles"`
+}
+
+func (s *Server) handleExchange(w http.ResponseWriter, r *http.Request) {
+	req := exchangeRequest{}
+	if err := decodeRequest(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	s.withPlayerTurn(w, r, func(g *liveGame, player string) error {
+		if g.classic == nil {
+			return fmt.Errorf("tiles cannot be exchanged in %s games", g.mode)
+		}
+		return g.classic.Exchange([]rune(strings.ToUpper(req.Tiles)))
+	})
+}
+
+func (s *Server) handlePass(w http.ResponseWriter, r *http.Request) {
+	s.withPlayerTurn(w, r, func(g *liveGame, player string) error {
+		if g.classic == nil {
+			return fmt.Errorf("turns cannot be passed in %s games", g.mode)
+		}
+		return g.classic.Pass()
+	})
+}
+
+func (s *Server) handleImage(w http.ResponseWriter, r *http.Request) {
+	width, err := imageSize(r, "width", defaultImageWidth)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	height, err := imageSize(r, "height", defaultImageHeight)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	g, err := s.game(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeGameError(w, err)
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.classic != nil {
+		canvas, err := scrabble.RenderClassicPNG(g.classic, width, height)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		_ = png.Encode(w, canvas.Image())
+		return
+	}
+	err = g.scrabulous.Do(func(game *scrabble.Scrabulous) error {
+		canvas, err := scrabble.RenderScrabulousPNG(game, width, height)
+		if err != nil {
+			return err
+		}
+		w.Header().Set("Content-Type", "image/png")
+		return png.Encode(w, canvas.Image())
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// withGame calls f for the requested game while holding its lock and responds with the caller's view of the game.
+func (s *Server) withGame(w http.ResponseWriter, r *http.Request, f func(g *liveGame, player string) (int, error)) {
+	g, err := s.game(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeGameError(w, err)
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	player, err := g.player(bearerToken(r))
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err)
+		return
+	}
+	if status, err := f(g, player); err != nil {
+		writeError(w, status, err)
+		return
+	}
+	s.writeView(w, http.StatusOK, g, player)
+}
+
+// withPlayerTurn calls f to take the caller's turn then saves the game.
+func (s *Server) withPlayerTurn(w http.ResponseWriter, r *http.Request, f func(g *liveGame, player string) error) {
+	g, err := s.game(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeGameError(w, err)
+		return
+	}
+	status, err := func() (int, error) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		player, err := g.player(bearerToken(r))
+		if err != nil {
+			return http.StatusUnauthorized, err
+		}
+		if player == "" {
+			return http.StatusUnauthorized, fmt.Errorf("spectators cannot play")
+		}
+		if g.classic != nil {
+			current, err := g.classic.GetCurrentPlayer()
+			if err != nil || current.Name != player {
+				return http.StatusForbidden, errNotYourTurn
+			}
+		}
+		if err := f(g, player); err != nil {
+			if errors.Is(err, errNotYourTurn) {
+				return http.StatusForbidden, err
+			}
+			return http.StatusBadRequest, err
+		}
+		return http.StatusOK, nil
+	}()
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	if err := s.save(r.Context(), g); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.withGame(w, r, func(g *liveGame, player string) (int, error) {
+		return http.StatusOK, nil
+	})
+}
+
+// writeView must be called while holding the game lock.
+func (s *Server) writeView(w http.ResponseWriter, status int, g *liveGame, player string) {
+	view, err := g.view(player)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, status, view)
+}
+
+func bearerToken(r *http.Request) string {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return strings.TrimSpace(token)
+}
+
+func imageSize(r *http.Request, name string, def int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size <= 0 || size > maxImageSize {
+		return 0, fmt.Errorf("%s must be between 1 and %d", name, maxImageSize)
+	}
+	return size, nil
+}
+
+func decodeRequest(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeGameError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrNotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,227 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/warmans/go-scrabble"
+)
+
+type testClient struct {
+	t   *testing.T
+	url string
+}
+
+func (c testClient) do(method, path, token string, body any, wantStatus int, out any) {
+	c.t.Helper()
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, c.url+path, &reqBody)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != wantStatus {
+		msg := &errorResponse{}
+		_ = json.NewDecoder(res.Body).Decode(msg)
+		c.t.Fatalf("%s %s returned %d (%s), want %d", method, path, res.StatusCode, msg.Error, wantStatus)
+	}
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+}
+
+func newTestServer(t *testing.T, srv *Server) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestServer_classic(t *testing.T) {
+	store := NewMemoryStore()
+	srv := New(store)
+	defer srv.Close()
+	ts := newTestServer(t, srv)
+	client := testClient{t: t, url: ts.URL}
+
+	game := &GameView{}
+	client.do(http.MethodPost, "/games", "", createRequest{Mode: ModeClassic, ChallengeRule: "Double"}, http.StatusBadRequest, nil)
+	client.do(http.MethodPost, "/games", "", createRequest{Mode: ModeClassic}, http.StatusCreated, game)
+	alice, bob := &joinResponse{}, &joinResponse{}
+	client.do(http.MethodPost, "/games/"+game.ID+"/players", "", joinRequest{Name: "alice"}, http.StatusCreated, alice)
+	client.do(http.MethodPost, "/games/"+game.ID+"/players", "", joinRequest{Name: "bob"}, http.StatusCreated, bob)
+	client.do(http.MethodPost, "/games/"+game.ID+"/players", "", joinRequest{Name: "bob"}, http.StatusConflict, nil)
+
+	view := &GameView{}
+	client.do(http.MethodGet, "/games/"+game.ID, alice.Token, nil, http.StatusOK, view)
+	rack := view.Classic.Players[0].Letters
+	if strings.Contains(rack, "?") || view.Classic.Players[1].Letters != "???????" {
+		t.Fatalf("alice sees racks %q and %q", rack, view.Classic.Players[1].Letters)
+	}
+	if strings.Trim(view.Classic.Bag, "?") != "" {
+		t.Errorf("bag is visible: %s", view.Classic.Bag)
+	}
+
+	client.do(http.MethodPost, "/games/"+game.ID+"/pass", bob.Token, nil, http.StatusForbidden, nil)
+	client.do(http.MethodPost, "/games/"+game.ID+"/pass", "", nil, http.StatusUnauthorized, nil)
+	client.do(http.MethodPost, "/games/"+game.ID+"/pass", "not-a-token", nil, http.StatusUnauthorized, nil)
+
+	// without a lexicon any word is accepted
+	word := strings.ReplaceAll(rack[:2], "_", "a")
+	client.do(http.MethodPost, "/games/"+game.ID+"/place", alice.Token, placeRequest{Coordinate: "8H", Word: word}, http.StatusOK, view)
+	if view.Classic.CurrentPlayer != 1 || len(view.Classic.Board.Tiles) != 2 {
+		t.Fatalf("unexpected game after placing a word: %+v", view.Classic)
+	}
+	client.do(http.MethodPost, "/games/"+game.ID+"/exchange", bob.Token, exchangeRequest{Tiles: "ZZZZ"}, http.StatusBadRequest, nil)
+
+	// a new server loads the game from the store
+	reloaded := New(store)
+	defer reloaded.Close()
+	ts2 := newTestServer(t, reloaded)
+	reloadedView := &GameView{}
+	testClient{t: t, url: ts2.URL}.do(http.MethodGet, "/games/"+game.ID, alice.Token, nil, http.StatusOK, reloadedView)
+	got, _ := json.Marshal(reloadedView)
+	want, _ := json.Marshal(view)
+	if !bytes.Equal(got, want) {
+		t.Errorf("reloaded game differs\n got: %s\nwant: %s", got, want)
+	}
+
+	res, err := http.Get(ts.URL + "/games/" + game.ID + "/board.png?width=600&height=400")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "image/png" {
+		t.Errorf("board image returned %d %s", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+	client.do(http.MethodGet, "/games/00000000000000000000000000000000", "", nil, http.StatusNotFound, nil)
+}
+
+func TestServer_scrabulousPlacesWordWhenStealWindowCloses(t *testing.T) {
+	store := NewMemoryStore()
+	clock := scrabble.NewFakeClock(time.Unix(0, 0))
+	srv := New(store, WithClock(clock))
+	defer srv.Close()
+	ts := newTestServer(t, srv)
+	client := testClient{t: t, url: ts.URL}
+
+	game := &GameView{}
+	client.do(http.MethodPost, "/games", "", createRequest{Mode: ModeScrabulous, StealTime: "10s"}, http.StatusCreated, game)
+	alice := &joinResponse{}
+	client.do(http.MethodPost, "/games/"+game.ID+"/players", "", joinRequest{Name: "alice"}, http.StatusCreated, alice)
+
+	view := &GameView{}
+	client.do(http.MethodGet, "/games/"+game.ID, "", nil, http.StatusOK, view)
+	if view.Scrabulous.Letters != "???????" {
+		t.Errorf("spectator can see the rack: %s", view.Scrabulous.Letters)
+	}
+	client.do(http.MethodGet, "/games/"+game.ID, alice.Token, nil, http.StatusOK, view)
+	word := strings.ReplaceAll(view.Scrabulous.Letters[:2], "_", "a")
+	client.do(http.MethodPost, "/games/"+game.ID+"/place", alice.Token, placeRequest{Coordinate: "8H", Word: word}, http.StatusOK, view)
+	if view.Scrabulous.GameState != scrabble.StateStealing {
+		t.Fatalf("steal window is not open")
+	}
+
+	clock.Advance(10 * time.Second)
+	deadline := time.Now().Add(time.Second)
+	for {
+		record, err := store.Load(context.Background(), game.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if placedAndRefilled(record) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("placed word was not saved")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	client.do(http.MethodGet, "/games/"+game.ID, alice.Token, nil, http.StatusOK, view)
+	if len(view.Scrabulous.PlacedWords) != 1 || view.Scrabulous.PlacedWords[0].Submitter != "alice" {
+		t.Errorf("unexpected placed words: %+v", view.Scrabulous.PlacedWords)
+	}
+}
+
+func placedAndRefilled(record *GameRecord) bool {
+	placed := false
+	for _, data := range record.Events {
+		e, err := scrabble.UnmarshalEvent(data)
+		if err != nil {
+			return false
+		}
+		switch e.(type) {
+		case scrabble.WordPlaced:
+			placed = true
+		case scrabble.TilesDrawn:
+			if placed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestPlaceRequest_parsePlacement(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     placeRequest
+		want    scrabble.Placement
+		wantErr bool
+	}{
+		{name: "cell index", req: placeRequest{Placement: "A8"}, want: scrabble.Placement{CellId: 8, Direction: scrabble.Across}},
+		{name: "coordinate", req: placeRequest{Coordinate: "A8"}, want: scrabble.Placement{CellId: 106, Direction: scrabble.Down}},
+		{name: "both", req: placeRequest{Placement: "A8", Coordinate: "A8"}, wantErr: true},
+		{name: "neither", req: placeRequest{}, wantErr: true},
+		{name: "coordinate as a cell index", req: placeRequest{Placement: "8H"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.req.parsePlacement()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePlacement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parsePlacement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceRequest_word(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{word: "CAT", want: "CAT"},
+		{word: "cat", want: "CAT"},
+		{word: "CaT", want: "CaT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := (placeRequest{Word: tt.word}).word(); got != tt.want {
+				t.Errorf("word() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var ErrNotFound = errors.New("game not found")
+
+type Mode string
+
+const (
+	ModeClassic    Mode = "classic"
+	ModeScrabulous Mode = "scrabulous"
+)
+
+// GameRecord is everything needed to rebuild a game. The game itself is stored as its events, see scrabble.Event.
+type GameRecord struct {
+	ID   string `json:"id"`
+	Mode Mode   `json:"mode"`
+	// Tokens maps each player's secret token to their name.
+	Tokens map[string]string `json:"tokens"`
+	// Events are encoded with scrabble.MarshalEvent.
+	Events []json.RawMessage `json:"events"`
+}
+
+func (r *GameRecord) clone() *GameRecord {
+	out := &GameRecord{ID: r.ID, Mode: r.Mode, Tokens: make(map[string]string, len(r.Tokens))}
+	for k, v := range r.Tokens {
+		out.Tokens[k] = v
+	}
+	out.Events = append(out.Events, r.Events...)
+	return out
+}
+
+// Store persists games. Implementations must be safe for concurrent use.
+type Store interface {
+	// Save creates or replaces the game with the record's ID.
+	Save(ctx context.Context, record *GameRecord) error
+	// Load returns ErrNotFound if there is no game with the ID.
+	Load(ctx context.Context, id string) (*GameRecord, error)
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]*GameRecord)}
+}
+
+// MemoryStore keeps games in memory so they are lost when the server stops.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]*GameRecord
+}
+
+func (s *MemoryStore) Save(_ context.Context, record *GameRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[record.ID] = record.clone()
+	return nil
+}
+
+func (s *MemoryStore) Load(_ context.Context, id string) (*GameRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return record.clone(), nil
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// FileStore keeps each game as a JSON file in a directory.
+type FileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+func (s *FileStore) Save(_ context.Context, record *GameRecord) error {
+	path, err := s.path(record.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// write to a temporary file first so a failed write cannot leave a partial game behind
+	tmp, err := os.CreateTemp(s.dir, record.ID+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FileStore) Load(_ context.Context, id string) (*GameRecord, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	record := &GameRecord{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, fmt.Errorf("invalid game file %s: %w", path, err)
+	}
+	return record, nil
+}
+
+func (s *FileStore) path(id string) (string, error) {
+	if !validID(id) {
+		return "", ErrNotFound
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestStores(t *testing.T) {
+	fileStore, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stores := map[string]Store{"memory": NewMemoryStore(), "file": fileStore}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			record := &GameRecord{
+				ID:     newID(),
+				Mode:   ModeClassic,
+				Tokens: map[string]string{"token": "alice"},
+				Events: []json.RawMessage{json.RawMessage(`{"type":"game_started","data":{}}`)},
+			}
+			if err := store.Save(context.Background(), record); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			got, err := store.Load(context.Background(), record.ID)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, record) {
+				t.Errorf("Load() = %+v, want %+v", got, record)
+			}
+			if _, err := store.Load(context.Background(), newID()); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Load() of a missing game error = %v, want ErrNotFound", err)
+			}
+			if _, err := store.Load(context.Background(), "../escape"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Load() of an invalid id error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
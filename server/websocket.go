@@ -0,0 +1,287 @@
+package server
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/warmans/go-scrabble"
+)
+
+const (
+	defaultCountdownInterval = time.Second
+	writeTimeout             = 10 * time.Second
+)
+
+type UpdateType string
+
+const (
+	// UpdateState is the full view of the game, it is sent when a client connects.
+	UpdateState UpdateType = "state"
+	// UpdateBoard gives the tiles that were placed on or removed from the board.
+	UpdateBoard UpdateType = "board"
+	// UpdateScores gives every player's score after any score changes.
+	UpdateScores UpdateType = "scores"
+	// UpdateRack gives the connected player's tiles after they change.
+	UpdateRack UpdateType = "rack"
+	// UpdateCountdown is sent regularly while a Scrabulous steal window is open.
+	UpdateCountdown UpdateType = "countdown"
+	// UpdateEvent is a game event encoded with scrabble.MarshalEvent. Tiles the client is not allowed to see are
+	// hidden in the same way as in the game view.
+	UpdateEvent UpdateType = "event"
+)
+
+// Update is a message sent to WebSocket clients. Only the field for the type of update is set.
+type Update struct {
+	Type      UpdateType      `json:"type"`
+	State     *GameView       `json:"state,omitempty"`
+	Board     *BoardDiff      `json:"board,omitempty"`
+	Scores    map[string]int  `json:"scores,omitempty"`
+	Rack      *string         `json:"rack,omitempty"`
+	Countdown *Countdown      `json:"countdown,omitempty"`
+	Event     json.RawMessage `json:"event,omitempty"`
+}
+
+type BoardDiff struct {
+	Placed []scrabble.CellSnapshot `json:"placed,omitempty"`
+	// Removed are the indexes of cells that were emptied e.g. by a withdrawn play.
+	Removed []int `json:"removed,omitempty"`
+}
+
+type Countdown struct {
+	ClosesAt time.Time `json:"closes_at"`
+	// Seconds is the number of whole seconds remaining, rounded up.
+	Seconds int `json:"seconds"`
+}
+
+// WithCountdownInterval sets how often countdown updates are sent to WebSocket clients.
+func WithCountdownInterval(interval time.Duration) Option {
+	return func(s *Server) {
+		s.countdownInterval = interval
+	}
+}
+
+// liveState is the part of a game that is diffed to find updates for a client.
+type liveState struct {
+	tiles  map[int]scrabble.CellSnapshot
+	scores map[string]int
+	rack   string
+	// placeWordAt is the end of the Scrabulous steal window or zero if it is closed.
+	placeWordAt time.Time
+	remaining   time.Duration
+}
+
+// state must be called while holding the game lock.
+func (g *liveGame) state(player string) (*GameView, *liveState, error) {
+	view, err := g.view(player)
+	if err != nil {
+		return nil, nil, err
+	}
+	state := &liveState{scores: make(map[string]int)}
+	if view.Classic != nil {
+		state.tiles = tilesByIndex(view.Classic.Board)
+		for _, p := range view.Classic.Players {
+			state.scores[p.Name] = p.Score
+			if p.Name == player {
+				state.rack = p.Letters
+			}
+		}
+		return view, state, nil
+	}
+	state.tiles = tilesByIndex(view.Scrabulous.Board)
+	if player != "" {
+		state.rack = view.Scrabulous.Letters
+	}
+	err = g.scrabulous.Do(func(game *scrabble.Scrabulous) error {
+		for _, score := range game.GetScores() {
+			state.scores[score.PlayerName] = score.Score
+		}
+		if game.PlaceWordAt != nil && game.GameState == scrabble.StateStealing {
+			state.placeWordAt = *game.PlaceWordAt
+			state.remaining = game.StealTimeRemaining()
+		}
+		return nil
+	})
+	return view, state, err
+}
+
+func tilesByIndex(board scrabble.BoardSnapshot) map[int]scrabble.CellSnapshot {
+	tiles := make(map[int]scrabble.CellSnapshot, len(board.Tiles))
+	for _, t := range board.Tiles {
+		tiles[t.Index] = t
+	}
+	return tiles
+}
+
+// diff returns the updates needed to get a client from the previous state to this one.
+func (s *liveState) diff(prev *liveState) []Update {
+	updates := make([]Update, 0)
+	board := &BoardDiff{}
+	for _, idx := range slices.Sorted(maps.Keys(s.tiles)) {
+		if old, ok := prev.tiles[idx]; !ok || old != s.tiles[idx] {
+			board.Placed = append(board.Placed, s.tiles[idx])
+		}
+	}
+	for _, idx := range slices.Sorted(maps.Keys(prev.tiles)) {
+		if _, ok := s.tiles[idx]; !ok {
+			board.Removed = append(board.Removed, idx)
+		}
+	}
+	if len(board.Placed) > 0 || len(board.Removed) > 0 {
+		updates = append(updates, Update{Type: UpdateBoard, Board: board})
+	}
+	if !maps.Equal(s.scores, prev.scores) {
+		updates = append(updates, Update{Type: UpdateScores, Scores: s.scores})
+	}
+	if s.rack != prev.rack {
+		rack := s.rack
+		updates = append(updates, Update{Type: UpdateRack, Rack: &rack})
+	}
+	return updates
+}
+
+func (s *liveState) countdown() *Countdown {
+	if s.placeWordAt.IsZero() {
+		return nil
+	}
+	return &Countdown{ClosesAt: s.placeWordAt, Seconds: int((s.remaining + time.Second - 1) / time.Second)}
+}
+
+// publicEvent returns the event as the player is allowed to see it. Events that only move tiles are not sent as
+// the rack and board updates already cover them.
+func publicEvent(e scrabble.Event, player string) (scrabble.Event, bool) {
+	switch e := e.(type) {
+	case scrabble.TilesDrawn, scrabble.TilesReturned, scrabble.GameRestored:
+		return nil, false
+	case scrabble.WordPlaced:
+		if e.Player != player {
+			e.Drawn = hideTiles(e.Drawn)
+		}
+		return e, true
+	case scrabble.TilesExchanged:
+		if e.Player != player {
+			e.Tiles = hideTiles(e.Tiles)
+			e.Drawn = hideTiles(e.Drawn)
+		}
+		return e, true
+	}
+	return e, true
+}
+
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	g, err := s.game(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeGameError(w, err)
+		return
+	}
+	// browsers cannot set headers on WebSocket requests so the token may also be given as a query parameter
+	token := bearerToken(r)
+	if token == "" {
+		token = strings.TrimSpace(r.URL.Query().Get("token"))
+	}
+	g.mu.Lock()
+	player, err := g.player(token)
+	g.mu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already responded
+		return
+	}
+	defer conn.Close()
+
+	watcher, stop := g.watch()
+	defer stop()
+
+	// clients do not send anything but reading is needed to notice when they disconnect
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	currentState := func() (*GameView, *liveState, error) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.state(player)
+	}
+	send := func(update Update) error {
+		if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+			return err
+		}
+		return conn.WriteJSON(update)
+	}
+
+	view, last, err := currentState()
+	if err != nil {
+		return
+	}
+	if err := send(Update{Type: UpdateState, State: view}); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(s.countdownInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-s.ctx.Done():
+			_ = conn.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down"),
+				time.Now().Add(writeTimeout),
+			)
+			return
+		case <-watcher.notify:
+			for _, e := range watcher.drain() {
+				public, ok := publicEvent(e, player)
+				if !ok {
+					continue
+				}
+				data, err := scrabble.MarshalEvent(public)
+				if err != nil {
+					return
+				}
+				if err := send(Update{Type: UpdateEvent, Event: data}); err != nil {
+					return
+				}
+			}
+			_, next, err := currentState()
+			if err != nil {
+				return
+			}
+			for _, update := range next.diff(last) {
+				if err := send(update); err != nil {
+					return
+				}
+			}
+			last = next
+		case <-ticker.C:
+			if g.scrabulous == nil {
+				continue
+			}
+			_, next, err := currentState()
+			if err != nil {
+				return
+			}
+			if countdown := next.countdown(); countdown != nil {
+				if err := send(Update{Type: UpdateCountdown, Countdown: countdown}); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/warmans/go-scrabble"
+)
+
+func dialGame(t *testing.T, serverURL, gameID, token string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(serverURL, "http") + "/games/" + gameID + "/ws?token=" + token
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	return conn
+}
+
+// readUntil reads updates until one of the given type arrives.
+func readUntil(t *testing.T, conn *websocket.Conn, updateType UpdateType) Update {
+	t.Helper()
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		update := Update{}
+		if err := conn.ReadJSON(&update); err != nil {
+			t.Fatalf("no %s update received: %v", updateType, err)
+		}
+		if update.Type == updateType {
+			return update
+		}
+	}
+}
+
+func TestServer_webSocketClassic(t *testing.T) {
+	srv := New(NewMemoryStore())
+	defer srv.Close()
+	ts := newTestServer(t, srv)
+	client := testClient{t: t, url: ts.URL}
+
+	game := &GameView{}
+	client.do(http.MethodPost, "/games", "", createRequest{Mode: ModeClassic}, http.StatusCreated, game)
+	alice, bob := &joinResponse{}, &joinResponse{}
+	client.do(http.MethodPost, "/games/"+game.ID+"/players", "", joinRequest{Name: "alice"}, http.StatusCreated, alice)
+	client.do(http.MethodPost, "/games/"+game.ID+"/players", "", joinRequest{Name: "bob"}, http.StatusCreated, bob)
+
+	conn := dialGame(t, ts.URL, game.ID, bob.Token)
+	state := readUntil(t, conn, UpdateState)
+	if state.State.You != "bob" || state.State.Classic.Players[0].Letters != "???????" {
+		t.Fatalf("unexpected initial state: %+v", state.State)
+	}
+
+	view := &GameView{}
+	client.do(http.MethodGet, "/games/"+game.ID, alice.Token, nil, http.StatusOK, view)
+	word := strings.ReplaceAll(view.Classic.Players[0].Letters[:2], "_", "a")
+	client.do(http.MethodPost, "/games/"+game.ID+"/place", alice.Token, placeRequest{Coordinate: "8H", Word: word}, http.StatusOK, view)
+
+	placed := readUntil(t, conn, UpdateEvent)
+	e, err := scrabble.UnmarshalEvent(placed.Event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wp, ok := e.(scrabble.WordPlaced); !ok || wp.Word != word || strings.Trim(wp.Drawn, "?") != "" {
+		t.Errorf("unexpected event: %+v", e)
+	}
+	board := readUntil(t, conn, UpdateBoard)
+	if len(board.Board.Placed) != 2 || board.Board.Placed[0].Index != 113 {
+		t.Errorf("unexpected board diff: %+v", board.Board)
+	}
+	scores := readUntil(t, conn, UpdateScores)
+	if scores.Scores["alice"] != view.Classic.Players[0].Score || scores.Scores["bob"] != 0 {
+		t.Errorf("unexpected scores: %v", scores.Scores)
+	}
+}
+
+func TestServer_webSocketCountdown(t *testing.T) {
+	clock := scrabble.NewFakeClock(time.Unix(0, 0))
+	srv := New(NewMemoryStore(), WithClock(clock), WithCountdownInterval(10*time.Millisecond))
+	defer srv.Close()
+	ts := newTestServer(t, srv)
+	client := testClient{t: t, url: ts.URL}
+
+	game := &GameView{}
+	client.do(http.MethodPost, "/games", "", createRequest{Mode: ModeScrabulous, StealTime: "10s"}, http.StatusCreated, game)
+	alice := &joinResponse{}
+	client.do(http.MethodPost, "/games/"+game.ID+"/players", "", joinRequest{Name: "alice"}, http.StatusCreated, alice)
+
+	conn := dialGame(t, ts.URL, game.ID, "")
+	readUntil(t, conn, UpdateState)
+
+	view := &GameView{}
+	client.do(http.MethodGet, "/games/"+game.ID, alice.Token, nil, http.StatusOK, view)
+	word := strings.ReplaceAll(view.Scrabulous.Letters[:2], "_", "a")
+	client.do(http.MethodPost, "/games/"+game.ID+"/place", alice.Token, placeRequest{Coordinate: "8H", Word: word}, http.StatusOK, view)
+
+	clock.Advance(3500 * time.Millisecond)
+	for {
+		countdown := readUntil(t, conn, UpdateCountdown)
+		if countdown.Countdown.Seconds == 7 {
+			if !countdown.Countdown.ClosesAt.Equal(time.Unix(10, 0)) {
+				t.Errorf("countdown closes at %v", countdown.Countdown.ClosesAt)
+			}
+			break
+		}
+	}
+
+	clock.Advance(7 * time.Second)
+	board := readUntil(t, conn, UpdateBoard)
+	if len(board.Board.Placed) != 2 {
+		t.Errorf("unexpected board diff: %+v", board.Board)
+	}
+}
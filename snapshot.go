@@ -0,0 +1,458 @@
+package scrabble
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+	"unicode"
+)
+
+// SnapshotVersion is the current version of the snapshot format. It is increased whenever the format changes so that
+// older snapshots can still be restored.
+const SnapshotVersion = 1
+
+type CellSnapshot struct {
+	Index  int           `json:"index"`
+	Letter string        `json:"letter"`
+	Blank  bool          `json:"blank,omitempty"`
+	Bonus  CellBonusType `json:"bonus,omitempty"`
+}
+
+type BoardSnapshot struct {
+	Size int `json:"size"`
+	// Tiles are the non-empty cells of the board.
+	Tiles []CellSnapshot `json:"tiles"`
+}
+
+type PlayerSnapshot struct {
+	Name    string `json:"name"`
+	Letters string `json:"letters"`
+	Score   int    `json:"score"`
+	Bot     string `json:"bot,omitempty"`
+}
+
+type MoveSnapshot struct {
+	Player      string   `json:"player"`
+	Type        TurnType `json:"type"`
+	Placement   string   `json:"placement,omitempty"`
+	Word        string   `json:"word,omitempty"`
+	Score       int      `json:"score"`
+	Explanation []string `json:"explanation,omitempty"`
+	Exchanged   string   `json:"exchanged,omitempty"`
+	Drawn       string   `json:"drawn,omitempty"`
+	Rack        string   `json:"rack,omitempty"`
+	RackAfter   string   `json:"rack_after,omitempty"`
+}
+
+type ClassicSnapshot struct {
+	Version        int              `json:"version"`
+	Board          BoardSnapshot    `json:"board"`
+	Players        []PlayerSnapshot `json:"players"`
+	CurrentPlayer  int              `json:"current_player"`
+	Bag            string           `json:"bag"`
+	NumWordsPlaced int              `json:"num_words_placed"`
+	Complete       bool             `json:"complete"`
+	EndReason      EndReason        `json:"end_reason,omitempty"`
+	ScorelessTurns int              `json:"scoreless_turns"`
+	ChallengeRule  ChallengeRule    `json:"challenge_rule"`
+	History        []MoveSnapshot   `json:"history"`
+}
+
+type PlacementResultSnapshot struct {
+	Cells        []CellSnapshot   `json:"cells"`
+	LettersSpent string           `json:"letters_spent"`
+	Touching     [][]CellSnapshot `json:"touching,omitempty"`
+}
+
+type WordSnapshot struct {
+	Submitter string                  `json:"submitter"`
+	Word      string                  `json:"word"`
+	Placement string                  `json:"placement"`
+	Stolen    bool                    `json:"stolen,omitempty"`
+	Result    PlacementResultSnapshot `json:"result"`
+}
+
+type ScrabulousSnapshot struct {
+	Version      int             `json:"version"`
+	Board        BoardSnapshot   `json:"board"`
+	Bag          string          `json:"bag"`
+	Letters      string          `json:"letters"`
+	PlacedWords  []WordSnapshot  `json:"placed_words"`
+	PendingWords []WordSnapshot  `json:"pending_words"`
+	PlaceWordAt  *time.Time      `json:"place_word_at,omitempty"`
+	Complete     bool            `json:"complete"`
+	GameState    ScrabulousState `json:"game_state"`
+	StealTime    string          `json:"steal_time"`
+}
+
+// Snapshot returns a serializable copy of the game state. The lexicon and other options the game was created with
+// are not included.
+func (g *Classic) Snapshot() *ClassicSnapshot {
+	snap := &ClassicSnapshot{
+		Version:        SnapshotVersion,
+		Board:          snapshotBoard(g.Board),
+		Players:        make([]PlayerSnapshot, 0, len(g.Players)),
+		CurrentPlayer:  g.CurrentPlayer,
+		Bag:            string(g.SpareLetters.Tiles()),
+		NumWordsPlaced: g.NumWordsPlaced,
+		Complete:       g.Complete,
+		EndReason:      g.EndReason,
+		ScorelessTurns: g.ScorelessTurns,
+		ChallengeRule:  g.ChallengeRule,
+		History:        make([]MoveSnapshot, 0, len(g.History)),
+	}
+	for _, p := range g.Players {
+		player := PlayerSnapshot{Name: p.Name, Letters: string(p.Letters), Score: p.Score}
+		if p.Bot != nil {
+			player.Bot = p.Bot.Name()
+		}
+		snap.Players = append(snap.Players, player)
+	}
+	for _, m := range g.History {
+		move := MoveSnapshot{
+			Player:      m.Player,
+			Type:        m.Type,
+			Word:        m.Word,
+			Score:       m.Score,
+			Explanation: m.Explanation,
+			Exchanged:   string(m.Exchanged),
+			Drawn:       string(m.Drawn),
+			Rack:        string(m.RackBefore),
+			RackAfter:   string(m.RackAfter),
+		}
+		if m.Type == TurnPlay || m.Type == TurnWithdrawn {
+			move.Placement = m.Placement.String()
+		}
+		snap.History = append(snap.History, move)
+	}
+	return snap
+}
+
+// Restore replaces the game state with the snapshot. Options such as the lexicon are kept from the existing game.
+func (g *Classic) Restore(snap *ClassicSnapshot) error {
+	return g.record(GameRestored{Classic: snap})
+}
+
+func (g *Classic) restoreSnapshot(snap *ClassicSnapshot) error {
+	if err := checkSnapshotVersion(snap.Version); err != nil {
+		return err
+	}
+	if err := snap.ChallengeRule.validate(); err != nil {
+		return err
+	}
+	board, err := restoreBoard(snap.Board)
+	if err != nil {
+		return err
+	}
+	players := make([]*Player, 0, len(snap.Players))
+	for _, p := range snap.Players {
+		if slices.ContainsFunc(players, func(other *Player) bool { return other.Name == p.Name }) {
+			return fmt.Errorf("player %s has already joined", p.Name)
+		}
+		player := &Player{Name: p.Name, Letters: []rune(p.Letters), Score: p.Score}
+		if p.Bot != "" {
+			// a bot restored into the game it was added to keeps the strategy it was given
+			if existing, err := g.getPlayerByName(p.Name); err == nil && existing.Bot != nil && existing.Bot.Name() == p.Bot {
+				player.Bot = existing.Bot
+			} else if player.Bot, err = BotStrategyByName(p.Bot); err != nil {
+				return err
+			}
+		}
+		players = append(players, player)
+	}
+	racks := make([]string, 0, len(snap.Players)+1)
+	for _, p := range snap.Players {
+		racks = append(racks, p.Letters)
+	}
+	if err := checkTiles(board, append(racks, snap.Bag)...); err != nil {
+		return err
+	}
+	history := make([]*Move, 0, len(snap.History))
+	for _, m := range snap.History {
+		move := &Move{
+			Player:      m.Player,
+			Type:        m.Type,
+			Word:        m.Word,
+			Score:       m.Score,
+			Explanation: m.Explanation,
+			Exchanged:   []rune(m.Exchanged),
+			Drawn:       []rune(m.Drawn),
+			RackBefore:  []rune(m.Rack),
+			RackAfter:   []rune(m.RackAfter),
+		}
+		if m.Placement != "" {
+			if move.Placement, err = ParsePlacement(m.Placement); err != nil {
+				return err
+			}
+		}
+		history = append(history, move)
+	}
+	if snap.CurrentPlayer < 0 || (len(players) > 0 && snap.CurrentPlayer >= len(players)) {
+		return fmt.Errorf("invalid current player: %d", snap.CurrentPlayer)
+	}
+
+	g.Board = board
+	g.Players = players
+	g.CurrentPlayer = snap.CurrentPlayer
+	if g.SpareLetters == nil {
+		g.SpareLetters = NewTileBag(nil)
+	}
+	g.SpareLetters.Fill([]rune(snap.Bag))
+	g.NumWordsPlaced = snap.NumWordsPlaced
+	g.Complete = snap.Complete
+	g.EndReason = snap.EndReason
+	g.ScorelessTurns = snap.ScorelessTurns
+	g.ChallengeRule = snap.ChallengeRule
+	g.History = history
+	g.lastPlay = nil
+	g.undoStates = nil
+	g.redoStates = nil
+	return nil
+}
+
+func (g *Classic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.Snapshot())
+}
+
+func (g *Classic) UnmarshalJSON(data []byte) error {
+	snap := &ClassicSnapshot{}
+	if err := json.Unmarshal(data, snap); err != nil {
+		return err
+	}
+	return g.Restore(snap)
+}
+
+// Snapshot returns a serializable copy of the game state. The lexicon and other options the game was created with
+// are not included.
+func (s *Scrabulous) Snapshot() *ScrabulousSnapshot {
+	snap := &ScrabulousSnapshot{
+		Version:      SnapshotVersion,
+		Board:        snapshotBoard(s.Board),
+		Bag:          string(s.SpareLetters.Tiles()),
+		Letters:      string(s.Letters),
+		PlacedWords:  snapshotWords(s.PlacedWords),
+		PendingWords: snapshotWords(s.PendingWords),
+		Complete:     s.Complete,
+		GameState:    s.GameState,
+		StealTime:    s.StealTime.String(),
+	}
+	if s.PlaceWordAt != nil {
+		placeAt := *s.PlaceWordAt
+		snap.PlaceWordAt = &placeAt
+	}
+	return snap
+}
+
+// Restore replaces the game state with the snapshot. Options such as the lexicon are kept from the existing game.
+func (s *Scrabulous) Restore(snap *ScrabulousSnapshot) error {
+	return s.record(GameRestored{Scrabulous: snap})
+}
+
+func (s *Scrabulous) restoreSnapshot(snap *ScrabulousSnapshot) error {
+	if err := checkSnapshotVersion(snap.Version); err != nil {
+		return err
+	}
+	board, err := restoreBoard(snap.Board)
+	if err != nil {
+		return err
+	}
+	if err := checkTiles(board, snap.Letters, snap.Bag); err != nil {
+		return err
+	}
+	placed, err := restoreWords(snap.PlacedWords, snap.Board.Size)
+	if err != nil {
+		return err
+	}
+	pending, err := restoreWords(snap.PendingWords, snap.Board.Size)
+	if err != nil {
+		return err
+	}
+	stealTime, err := time.ParseDuration(snap.StealTime)
+	if err != nil {
+		return fmt.Errorf("invalid steal time: %w", err)
+	}
+
+	s.Board = board
+	if s.SpareLetters == nil {
+		s.SpareLetters = NewTileBag(nil)
+	}
+	s.SpareLetters.Fill([]rune(snap.Bag))
+	s.Letters = []rune(snap.Letters)
+	s.PlacedWords = placed
+	s.PendingWords = pending
+	s.PlaceWordAt = nil
+	if snap.PlaceWordAt != nil {
+		placeAt := *snap.PlaceWordAt
+		s.PlaceWordAt = &placeAt
+	}
+	s.Complete = snap.Complete
+	s.GameState = snap.GameState
+	s.StealTime = stealTime
+	return nil
+}
+
+func (s *Scrabulous) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Snapshot())
+}
+
+func (s *Scrabulous) UnmarshalJSON(data []byte) error {
+	snap := &ScrabulousSnapshot{}
+	if err := json.Unmarshal(data, snap); err != nil {
+		return err
+	}
+	return s.Restore(snap)
+}
+
+func checkSnapshotVersion(version int) error {
+	if version < 1 || version > SnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version: %d", version)
+	}
+	return nil
+}
+
+func snapshotCell(c Cell) CellSnapshot {
+	return CellSnapshot{Index: c.Index, Letter: c.String(), Blank: c.IsBlank, Bonus: c.Bonus}
+}
+
+func snapshotCells(cells []Cell) []CellSnapshot {
+	out := make([]CellSnapshot, 0, len(cells))
+	for _, c := range cells {
+		out = append(out, snapshotCell(c))
+	}
+	return out
+}
+
+func restoreCells(cells []CellSnapshot, size int) ([]Cell, error) {
+	out := make([]Cell, 0, len(cells))
+	for _, c := range cells {
+		letter := []rune(c.Letter)
+		if len(letter) != 1 || letter[0] < 'A' || letter[0] > 'Z' {
+			return nil, fmt.Errorf("invalid letter in cell %d: %s", c.Index, c.Letter)
+		}
+		if size < 1 || c.Index < 1 || c.Index > size*size {
+			return nil, fmt.Errorf("invalid cell index: %d", c.Index)
+		}
+		out = append(out, Cell{
+			Index:       c.Index,
+			Char:        letter[0],
+			Coordinates: [2]int{(c.Index - 1) / size, (c.Index - 1) % size},
+			Bonus:       c.Bonus,
+			IsBlank:     c.Blank,
+		})
+	}
+	return out, nil
+}
+
+func snapshotBoard(b Board) BoardSnapshot {
+	snap := BoardSnapshot{Size: len(b), Tiles: make([]CellSnapshot, 0)}
+	for _, row := range b {
+		for _, cell := range row {
+			if !cell.Empty() {
+				tile := snapshotCell(cell)
+				tile.Bonus = NoBonusType
+				snap.Tiles = append(snap.Tiles, tile)
+			}
+		}
+	}
+	return snap
+}
+
+func restoreBoard(snap BoardSnapshot) (Board, error) {
+	if snap.Size != StandardBoardSize {
+		return nil, fmt.Errorf("unsupported board size: %d", snap.Size)
+	}
+	tiles, err := restoreCells(snap.Tiles, snap.Size)
+	if err != nil {
+		return nil, err
+	}
+	board := NewBoard(snap.Size)
+	for _, t := range tiles {
+		letter := t.Char
+		if t.IsBlank {
+			letter = unicode.ToLower(letter)
+		}
+		if _, placed := board.SetCell(int64(t.Index), letter); !placed {
+			return nil, fmt.Errorf("more than one tile in cell %d", t.Index)
+		}
+	}
+	return board, nil
+}
+
+// checkTiles checks the racks and bag only hold letters and blanks, and that together with the board they make up the
+// full LetterDistribution.
+func checkTiles(board Board, tiles ...string) error {
+	counts := make(map[rune]int)
+	for _, row := range board {
+		for _, cell := range row {
+			if cell.IsBlank {
+				counts[blankLetter]++
+			} else if !cell.Empty() {
+				counts[cell.Char]++
+			}
+		}
+	}
+	for _, t := range tiles {
+		for _, l := range t {
+			if _, ok := LetterScores[l]; !ok {
+				return fmt.Errorf("invalid tile: %q", l)
+			}
+			counts[l]++
+		}
+	}
+	if !maps.Equal(counts, LetterDistribution) {
+		return fmt.Errorf("tiles do not match the letter distribution")
+	}
+	return nil
+}
+
+func snapshotWords(words []*Word) []WordSnapshot {
+	out := make([]WordSnapshot, 0, len(words))
+	for _, w := range words {
+		word := WordSnapshot{
+			Submitter: w.Submitter,
+			Word:      string(w.Word),
+			Placement: w.Place.String(),
+			Stolen:    w.Stolen,
+		}
+		if w.Result != nil {
+			word.Result.Cells = snapshotCells(w.Result.Cells)
+			word.Result.LettersSpent = string(w.Result.LettersSpent)
+			for _, t := range w.Result.Touching {
+				word.Result.Touching = append(word.Result.Touching, snapshotCells(t))
+			}
+		}
+		out = append(out, word)
+	}
+	return out
+}
+
+func restoreWords(words []WordSnapshot, size int) ([]*Word, error) {
+	out := make([]*Word, 0, len(words))
+	for _, w := range words {
+		place, err := ParsePlacement(w.Placement)
+		if err != nil {
+			return nil, err
+		}
+		result := &PlacementResult{LettersSpent: []rune(w.Result.LettersSpent), Touching: make([][]Cell, 0)}
+		if result.Cells, err = restoreCells(w.Result.Cells, size); err != nil {
+			return nil, err
+		}
+		for _, t := range w.Result.Touching {
+			touching, err := restoreCells(t, size)
+			if err != nil {
+				return nil, err
+			}
+			result.Touching = append(result.Touching, touching)
+		}
+		out = append(out, &Word{
+			Submitter: w.Submitter,
+			Word:      []rune(w.Word),
+			Place:     place,
+			Result:    result,
+			Stolen:    w.Stolen,
+		})
+	}
+	return out, nil
+}
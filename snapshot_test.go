@@ -0,0 +1,241 @@
+package scrabble
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSnapshotClassic(t *testing.T) *Classic {
+	t.Helper()
+	game := newTestClassicGame(t, 1, "alice", "bob")
+	game.Players[0].Letters = swapRackOrFail(t, game.SpareLetters, game.Players[0].Letters, "CA_XYEQ")
+	if err := game.PlaceWord(Placement{CellId: 112, Direction: Across}, "CAt"); err != nil {
+		t.Fatalf("PlaceWord() error = %v", err)
+	}
+	player, _ := game.GetCurrentPlayer()
+	if err := game.Exchange(player.Letters[:2]); err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if err := game.Pass(); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+	return game
+}
+
+func newTestSnapshotScrabulous(t *testing.T) *Scrabulous {
+	t.Helper()
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	game := NewScrabulousGame(time.Minute, WithClock(clock), WithSeed(1))
+	game.Letters = swapRackOrFail(t, game.SpareLetters, game.Letters, "CA_SXYZ")
+	if _, err := game.CreatePendingWord(Placement{CellId: 112, Direction: Across}, "CAt", "alice"); err != nil {
+		t.Fatalf("CreatePendingWord() error = %v", err)
+	}
+	clock.Advance(time.Minute * 2)
+	if err := game.TryPlacePendingWord(); err != nil {
+		t.Fatalf("TryPlacePendingWord() error = %v", err)
+	}
+	game.Letters = swapRackOrFail(t, game.SpareLetters, game.Letters, "S_XYZQE")
+	if _, err := game.CreatePendingWord(Placement{CellId: 112, Direction: Across}, "CATs", "bob"); err != nil {
+		t.Fatalf("CreatePendingWord() error = %v", err)
+	}
+	clock.Advance(time.Second * 10)
+	if _, err := game.CreatePendingWord(Placement{CellId: 112, Direction: Across}, "CATS", "carol"); err != nil {
+		t.Fatalf("CreatePendingWord() error = %v", err)
+	}
+	return game
+}
+
+func TestClassic_MarshalJSON(t *testing.T) {
+	game := newTestSnapshotClassic(t)
+	data, err := json.Marshal(game)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	restored := NewClassicGame()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(restored.Snapshot(), game.Snapshot()) {
+		t.Errorf("restored snapshot = %+v, want %+v", restored.Snapshot(), game.Snapshot())
+	}
+	if !reflect.DeepEqual(restored.Board, game.Board) {
+		t.Errorf("restored board differs")
+	}
+	if cell := restored.Board.GetCell(114, CellFull); cell == nil || cell.Char != 'T' || !cell.IsBlank || cell.LetterScore() != 0 {
+		t.Errorf("restored cell 114 = %+v, want a blank T", cell)
+	}
+	if len(restored.History) != 3 || restored.History[0].Placement != (Placement{CellId: 112, Direction: Across}) {
+		t.Errorf("restored history = %v", restored.History)
+	}
+
+	// the restored game carries on from the same point
+	if player, _ := restored.GetCurrentPlayer(); player.Name != "bob" {
+		t.Errorf("current player = %s, want bob", player.Name)
+	}
+	if err := restored.Pass(); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+	if restored.ScorelessTurns != 3 {
+		t.Errorf("ScorelessTurns = %d, want 3", restored.ScorelessTurns)
+	}
+}
+
+func TestScrabulous_MarshalJSON(t *testing.T) {
+	game := newTestSnapshotScrabulous(t)
+	if len(game.PendingWords) != 2 || len(game.PlacedWords) != 1 {
+		t.Fatalf("got %d placed and %d pending words, want 1 and 2", len(game.PlacedWords), len(game.PendingWords))
+	}
+	data, err := json.Marshal(game)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	restored := NewScrabulousGame(0)
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(restored.Snapshot(), game.Snapshot()) {
+		t.Errorf("restored snapshot = %+v, want %+v", restored.Snapshot(), game.Snapshot())
+	}
+	if !reflect.DeepEqual(restored.Board, game.Board) {
+		t.Errorf("restored board differs")
+	}
+	if !reflect.DeepEqual(restored.PendingWords, game.PendingWords) {
+		t.Errorf("restored pending words = %v, want %v", restored.PendingWords, game.PendingWords)
+	}
+	if !reflect.DeepEqual(restored.PlacedWords, game.PlacedWords) {
+		t.Errorf("restored placed words = %v, want %v", restored.PlacedWords, game.PlacedWords)
+	}
+	if cells := restored.PendingWords[0].Result.Cells; len(cells) != 4 || !cells[2].IsBlank || !cells[3].IsBlank {
+		t.Errorf("restored pending word cells = %+v, want blank T and S", cells)
+	}
+	if restored.PlaceWordAt == nil || !restored.PlaceWordAt.Equal(*game.PlaceWordAt) {
+		t.Errorf("PlaceWordAt = %v, want %v", restored.PlaceWordAt, game.PlaceWordAt)
+	}
+	if restored.StealTime != time.Minute || restored.GameState != StateStealing {
+		t.Errorf("restored steal time %s in state %s", restored.StealTime, restored.GameState)
+	}
+}
+
+func TestUnmarshalJSON_zeroValue(t *testing.T) {
+	classic := newTestSnapshotClassic(t)
+	data, err := json.Marshal(classic)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	restoredClassic := &Classic{}
+	if err := json.Unmarshal(data, restoredClassic); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(restoredClassic.Snapshot(), classic.Snapshot()) {
+		t.Errorf("restored snapshot = %+v, want %+v", restoredClassic.Snapshot(), classic.Snapshot())
+	}
+	if err := restoredClassic.Pass(); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+
+	scrabulous := newTestSnapshotScrabulous(t)
+	data, err = json.Marshal(scrabulous)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	restoredScrabulous := &Scrabulous{}
+	if err := json.Unmarshal(data, restoredScrabulous); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(restoredScrabulous.Snapshot(), scrabulous.Snapshot()) {
+		t.Errorf("restored snapshot = %+v, want %+v", restoredScrabulous.Snapshot(), scrabulous.Snapshot())
+	}
+}
+
+func TestClassic_Restore_invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(snap *ClassicSnapshot)
+	}{
+		{name: "version zero", modify: func(snap *ClassicSnapshot) { snap.Version = 0 }},
+		{name: "unknown version", modify: func(snap *ClassicSnapshot) { snap.Version = SnapshotVersion + 1 }},
+		{name: "no board size", modify: func(snap *ClassicSnapshot) { snap.Board.Size = 0 }},
+		{name: "small board", modify: func(snap *ClassicSnapshot) { snap.Board.Size = 7 }},
+		{name: "large board", modify: func(snap *ClassicSnapshot) { snap.Board.Size = 21 }},
+		{name: "empty tile", modify: func(snap *ClassicSnapshot) { snap.Board.Tiles[0].Letter = "" }},
+		{name: "two letter tile", modify: func(snap *ClassicSnapshot) { snap.Board.Tiles[0].Letter = "CA" }},
+		{name: "lower case tile", modify: func(snap *ClassicSnapshot) { snap.Board.Tiles[0].Letter = "c" }},
+		{name: "blank tile without letter", modify: func(snap *ClassicSnapshot) { snap.Board.Tiles[0].Letter = "_" }},
+		{name: "digit tile", modify: func(snap *ClassicSnapshot) { snap.Board.Tiles[0].Letter = "1" }},
+		{name: "tile index zero", modify: func(snap *ClassicSnapshot) { snap.Board.Tiles[0].Index = 0 }},
+		{name: "tile off the board", modify: func(snap *ClassicSnapshot) { snap.Board.Tiles[0].Index = 226 }},
+		{name: "two tiles in a cell", modify: func(snap *ClassicSnapshot) { snap.Board.Tiles[1].Index = snap.Board.Tiles[0].Index }},
+		{name: "invalid placement", modify: func(snap *ClassicSnapshot) { snap.History[0].Placement = "X1" }},
+		{name: "unknown challenge rule", modify: func(snap *ClassicSnapshot) { snap.ChallengeRule = "Double" }},
+		{name: "unknown bot", modify: func(snap *ClassicSnapshot) { snap.Players[1].Bot = "cheat" }},
+		{name: "duplicate player", modify: func(snap *ClassicSnapshot) { snap.Players[1].Name = snap.Players[0].Name }},
+		{name: "invalid rack tile", modify: func(snap *ClassicSnapshot) { snap.Players[0].Letters = "€€€" }},
+		{name: "invalid bag tile", modify: func(snap *ClassicSnapshot) { snap.Bag = snap.Bag[1:] + "a" }},
+		{name: "extra tiles", modify: func(snap *ClassicSnapshot) { snap.Players[1].Letters = strings.Repeat("Z", 20) }},
+		{name: "missing tiles", modify: func(snap *ClassicSnapshot) { snap.Bag = snap.Bag[1:] }},
+		{name: "swapped tiles", modify: func(snap *ClassicSnapshot) { snap.Bag = strings.Replace(snap.Bag, "E", "Q", 1) }},
+		{name: "invalid current player", modify: func(snap *ClassicSnapshot) { snap.CurrentPlayer = 2 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap := newTestSnapshotClassic(t).Snapshot()
+			tt.modify(snap)
+
+			game := newTestClassicGame(t, 2, "carol")
+			before := game.Snapshot()
+			if err := game.Restore(snap); err == nil {
+				t.Fatalf("Restore() expected error")
+			}
+			if !reflect.DeepEqual(game.Snapshot(), before) {
+				t.Errorf("failed restore changed the game")
+			}
+		})
+	}
+}
+
+func TestScrabulous_Restore_invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(snap *ScrabulousSnapshot)
+	}{
+		{name: "unknown version", modify: func(snap *ScrabulousSnapshot) { snap.Version = SnapshotVersion + 1 }},
+		{name: "small board", modify: func(snap *ScrabulousSnapshot) { snap.Board.Size = 7 }},
+		{name: "corrupt board tile", modify: func(snap *ScrabulousSnapshot) { snap.Board.Tiles[0].Letter = "?" }},
+		{name: "corrupt pending word tile", modify: func(snap *ScrabulousSnapshot) { snap.PendingWords[0].Result.Cells[0].Letter = "" }},
+		{name: "pending word tile off the board", modify: func(snap *ScrabulousSnapshot) { snap.PendingWords[1].Result.Cells[3].Index = 300 }},
+		{name: "corrupt placed word tile", modify: func(snap *ScrabulousSnapshot) { snap.PlacedWords[0].Result.Cells[1].Letter = "AB" }},
+		{name: "invalid placement", modify: func(snap *ScrabulousSnapshot) { snap.PendingWords[0].Placement = "" }},
+		{name: "invalid steal time", modify: func(snap *ScrabulousSnapshot) { snap.StealTime = "soon" }},
+		{name: "invalid rack tile", modify: func(snap *ScrabulousSnapshot) { snap.Letters = "?" + snap.Letters[1:] }},
+		{name: "extra tiles", modify: func(snap *ScrabulousSnapshot) { snap.Letters += "ZZ" }},
+		{name: "missing bag tiles", modify: func(snap *ScrabulousSnapshot) { snap.Bag = "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap := newTestSnapshotScrabulous(t).Snapshot()
+			tt.modify(snap)
+
+			game := NewScrabulousGame(time.Second)
+			before := game.Snapshot()
+			if err := game.Restore(snap); err == nil {
+				t.Fatalf("Restore() expected error")
+			}
+			if !reflect.DeepEqual(game.Snapshot(), before) {
+				t.Errorf("failed restore changed the game")
+			}
+		})
+	}
+}
+
+func TestClassic_UnmarshalJSON_invalid(t *testing.T) {
+	for _, data := range []string{`{`, `{"version": 99}`, `{"version": 1, "board": {"size": 15, "tiles": [{"index": 1, "letter": "AA"}]}}`} {
+		if err := json.Unmarshal([]byte(data), NewClassicGame()); err == nil {
+			t.Errorf("Unmarshal(%s) expected error", data)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package scrabble
+
+import "slices"
+
+type subscriber struct {
+	id int
+	f  func(Event)
+}
+
+// subscribers are called in the order they subscribed.
+type subscribers struct {
+	nextID int
+	subs   []subscriber
+}
+
+func (s *subscribers) add(f func(Event)) func() {
+	s.nextID++
+	id := s.nextID
+	s.subs = append(s.subs, subscriber{id: id, f: f})
+	return func() {
+		s.subs = slices.DeleteFunc(s.subs, func(sub subscriber) bool {
+			return sub.id == id
+		})
+	}
+}
+
+func (s *subscribers) publish(events ...Event) {
+	// subscribers may unsubscribe while they are being called
+	for _, sub := range slices.Clone(s.subs) {
+		for _, e := range events {
+			sub.f(e)
+		}
+	}
+}
+
+// Subscribe calls f with every event recorded by the game followed by any notifications such as TurnChanged that
+// result from it. f is called synchronously so must not take turns itself. Events are not sent while a game is
+// being replayed. Call the returned function to unsubscribe.
+func (g *Classic) Subscribe(f func(Event)) (unsubscribe func()) {
+	return g.subscribers.add(f)
+}
+
+// classicObservation is the state notifications are derived from.
+type classicObservation struct {
+	player   string
+	bag      int
+	complete bool
+}
+
+func (g *Classic) observe() classicObservation {
+	obs := classicObservation{complete: g.Complete}
+	if g.SpareLetters != nil {
+		// a zero value game has no bag until a snapshot is restored into it
+		obs.bag = g.SpareLetters.Len()
+	}
+	if player, err := g.GetCurrentPlayer(); err == nil {
+		obs.player = player.Name
+	}
+	return obs
+}
+
+// notifications describes how the game changed since it was observed.
+func (g *Classic) notifications(before classicObservation) []Event {
+	after := g.observe()
+	events := make([]Event, 0)
+	if after.bag < before.bag && after.bag < NumPlayerLetters {
+		events = append(events, BagLow{Remaining: after.bag})
+	}
+	if !after.complete && before.player != "" && after.player != before.player {
+		events = append(events, TurnChanged{Player: after.player, Previous: before.player})
+	}
+	if after.complete && !before.complete {
+		if result, err := g.Result(); err == nil {
+			events = append(events, GameComplete{Result: result})
+		}
+	}
+	return events
+}
+
+// Subscribe calls f with every event recorded by the game followed by any notifications such as
+// StealWindowOpened that result from it. f is called synchronously so must not change the game itself. Events
+// are not sent while a game is being replayed. Call the returned function to unsubscribe.
+func (s *Scrabulous) Subscribe(f func(Event)) (unsubscribe func()) {
+	return s.subscribers.add(f)
+}
+
+type scrabulousObservation struct {
+	state    ScrabulousState
+	bag      int
+	complete bool
+}
+
+func (s *Scrabulous) observe() scrabulousObservation {
+	obs := scrabulousObservation{state: s.GameState, complete: s.Complete}
+	if s.SpareLetters != nil {
+		obs.bag = s.SpareLetters.Len()
+	}
+	return obs
+}
+
+func (s *Scrabulous) notifications(before scrabulousObservation, cause Event) []Event {
+	after := s.observe()
+	events := make([]Event, 0)
+	if before.state != StateStealing && after.state == StateStealing {
+		if best := s.bestPendingWord(); best != nil && s.PlaceWordAt != nil {
+			events = append(events, StealWindowOpened{
+				Player:   best.Submitter,
+				Word:     string(best.Word),
+				Score:    best.Result.Score(),
+				ClosesAt: *s.PlaceWordAt,
+			})
+		}
+	}
+	if before.state == StateStealing && after.state != StateStealing {
+		closed := StealWindowClosed{}
+		if placed, ok := cause.(WordPlaced); ok {
+			closed = StealWindowClosed{Player: placed.Player, Word: placed.Word, Score: placed.Score}
+		}
+		events = append(events, closed)
+	}
+	if after.bag < before.bag && after.bag < NumPlayerLetters {
+		events = append(events, BagLow{Remaining: after.bag})
+	}
+	if after.complete && !before.complete {
+		if result, err := s.Result(); err == nil {
+			events = append(events, GameComplete{Result: result})
+		}
+	}
+	return events
+}
@@ -0,0 +1,81 @@
+package scrabble
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+)
+
+// TileBag holds the letters that have not yet been drawn. Draws are made using the bag's own random source so
+// a game created with the same seed and given the same moves will always draw the same tiles.
+type TileBag struct {
+	tiles []rune
+	rng   *rand.Rand
+}
+
+// NewTileBag creates a bag containing the standard letter distribution. If src is nil a randomly seeded source
+// is used.
+func NewTileBag(src rand.Source) *TileBag {
+	if src == nil {
+		src = rand.NewPCG(rand.Uint64(), rand.Uint64())
+	}
+	return &TileBag{tiles: makeLetterBag(), rng: rand.New(src)}
+}
+
+func (b *TileBag) Len() int {
+	return len(b.tiles)
+}
+
+// Tiles returns a copy of the tiles remaining in the bag.
+func (b *TileBag) Tiles() []rune {
+	return slices.Clone(b.tiles)
+}
+
+// Draw removes up to n random tiles from the bag.
+func (b *TileBag) Draw(n int) []rune {
+	drawn := b.pick(n)
+	// the picked tiles are always in the bag
+	_ = b.Take(drawn...)
+	return drawn
+}
+
+// pick chooses up to n random tiles without removing them from the bag. Games pick the tiles a player will draw
+// then record them being taken so the same tiles are drawn when the game is replayed.
+func (b *TileBag) pick(n int) []rune {
+	remaining := slices.Clone(b.tiles)
+	picked := make([]rune, 0, max(n, 0))
+	for range n {
+		if len(remaining) == 0 {
+			break
+		}
+		idx := b.rng.IntN(len(remaining))
+		picked = append(picked, remaining[idx])
+		remaining = slices.Delete(remaining, idx, idx+1)
+	}
+	return picked
+}
+
+func (b *TileBag) contains(tiles []rune) bool {
+	_, foundAll := takeLetters(b.tiles, tiles)
+	return foundAll
+}
+
+// Take removes specific tiles from the bag, failing if any of them are not in the bag.
+func (b *TileBag) Take(tiles ...rune) error {
+	remaining, foundAll := takeLetters(b.tiles, tiles)
+	if !foundAll {
+		return fmt.Errorf("bag does not contain all of: %s", string(tiles))
+	}
+	b.tiles = remaining
+	return nil
+}
+
+// Return puts tiles back in the bag.
+func (b *TileBag) Return(tiles ...rune) {
+	b.tiles = append(b.tiles, tiles...)
+}
+
+// Fill replaces the contents of the bag.
+func (b *TileBag) Fill(tiles []rune) {
+	b.tiles = slices.Clone(tiles)
+}
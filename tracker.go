@@ -0,0 +1,106 @@
+package scrabble
+
+import (
+	"maps"
+	"slices"
+)
+
+// UnseenTiles returns the number of each tile the given player has not seen: the full letter distribution minus
+// the tiles on the board and the player's own rack. Blanks are counted as '_'.
+func (g *Classic) UnseenTiles(playerIdx int) (map[rune]int, error) {
+	player, err := g.getPlayer(playerIdx)
+	if err != nil {
+		return nil, err
+	}
+	unseen := maps.Clone(LetterDistribution)
+	for _, row := range g.Board {
+		for _, cell := range row {
+			if cell.Empty() {
+				continue
+			}
+			if cell.IsBlank {
+				unseen[blankLetter]--
+			} else {
+				unseen[cell.Char]--
+			}
+		}
+	}
+	for _, l := range player.Letters {
+		unseen[l]--
+	}
+	for l, count := range unseen {
+		if count <= 0 {
+			delete(unseen, l)
+		}
+	}
+	return unseen, nil
+}
+
+// DrawProbability returns the chance of the given player drawing all the wanted tiles (e.g. "SS" for two S) in
+// the given number of draws. Tiles held by other players are unknown so all unseen tiles are assumed to be in
+// the bag. The number of draws is limited to the number of tiles left in the bag.
+func (g *Classic) DrawProbability(playerIdx int, want []rune, draws int) (float64, error) {
+	unseen, err := g.UnseenTiles(playerIdx)
+	if err != nil {
+		return 0, err
+	}
+	return ProbabilityOfDrawing(unseen, want, min(draws, g.SpareLetters.Len())), nil
+}
+
+// ProbabilityOfDrawing returns the chance of drawing at least the wanted tiles when drawing without replacement
+// from the given pool of tiles.
+func ProbabilityOfDrawing(pool map[rune]int, want []rune, draws int) float64 {
+	total := 0
+	for _, count := range pool {
+		total += count
+	}
+	if draws > total {
+		draws = total
+	}
+	if len(want) == 0 {
+		return 1
+	}
+	if draws <= 0 {
+		return 0
+	}
+
+	needed := map[rune]int{}
+	for _, l := range want {
+		needed[l]++
+	}
+	letters := slices.Sorted(maps.Keys(needed))
+	others := total
+	for _, l := range letters {
+		if pool[l] < needed[l] {
+			return 0
+		}
+		others -= pool[l]
+	}
+
+	// sum the hypergeometric probability of every combination of draws that contains at least the wanted tiles
+	var ways func(i, remaining int) float64
+	ways = func(i, remaining int) float64 {
+		if i == len(letters) {
+			return binomial(others, remaining)
+		}
+		l := letters[i]
+		sum := 0.0
+		for x := needed[l]; x <= min(pool[l], remaining); x++ {
+			sum += binomial(pool[l], x) * ways(i+1, remaining-x)
+		}
+		return sum
+	}
+	return ways(0, draws) / binomial(total, draws)
+}
+
+func binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	k = min(k, n-k)
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
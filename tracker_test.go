@@ -0,0 +1,172 @@
+package scrabble
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"testing"
+)
+
+func unseenLetters(unseen map[rune]int) string {
+	letters := []rune{}
+	for l, count := range unseen {
+		for range count {
+			letters = append(letters, l)
+		}
+	}
+	slices.Sort(letters)
+	return string(letters)
+}
+
+// newTestTrackerGame has every tile that is not on a rack or in the bag on the board.
+func newTestTrackerGame(t *testing.T, racks []string, bag string) *Classic {
+	t.Helper()
+	game := newTestClassicGame(t, 1, "alice", "bob")
+	rest := []rune{}
+	for _, l := range slices.Sorted(maps.Keys(LetterDistribution)) {
+		for range LetterDistribution[l] {
+			rest = append(rest, l)
+		}
+	}
+	for i, rack := range racks {
+		game.Players[i].Letters = []rune(rack)
+		rest = takeLettersOrFail(t, rest, []rune(rack))
+	}
+	game.SpareLetters.Fill([]rune(bag))
+	rest = takeLettersOrFail(t, rest, []rune(bag))
+	for i, l := range rest {
+		if l == blankLetter {
+			l = 'e'
+		}
+		if _, placed := game.Board.SetCell(int64(i+1), l); !placed {
+			t.Fatalf("failed to place %c", l)
+		}
+	}
+	return game
+}
+
+func TestClassic_UnseenTiles(t *testing.T) {
+	game := newTestTrackerGame(t, []string{"ABCDEF_", "HIJKLMN"}, "SS")
+	tests := []struct {
+		player int
+		want   string
+	}{
+		// each player has not seen the bag or the other player's rack
+		{player: 0, want: "HIJKLMNSS"},
+		{player: 1, want: "ABCDEFSS_"},
+	}
+	for _, tt := range tests {
+		unseen, err := game.UnseenTiles(tt.player)
+		if err != nil {
+			t.Fatalf("UnseenTiles() error = %v", err)
+		}
+		if got := unseenLetters(unseen); got != tt.want {
+			t.Errorf("player %d unseen tiles = %s, want %s", tt.player, got, tt.want)
+		}
+	}
+	if _, err := game.UnseenTiles(2); err == nil {
+		t.Errorf("UnseenTiles() expected error for unknown player")
+	}
+}
+
+func TestClassic_DrawProbability(t *testing.T) {
+	game := newTestTrackerGame(t, []string{"ABCDEFG", "HIJKLMN"}, "S")
+	tests := []struct {
+		want  string
+		draws int
+		exact float64
+	}{
+		// bob can't see alice's rack so the S could be any of eight tiles, but there's only one left to draw
+		{want: "S", draws: NumPlayerLetters, exact: 1.0 / 8},
+		{want: "A", draws: 1, exact: 1.0 / 8},
+		{want: "AS", draws: NumPlayerLetters, exact: 0},
+		{want: "H", draws: 1, exact: 0},
+	}
+	for _, tt := range tests {
+		got, err := game.DrawProbability(1, []rune(tt.want), tt.draws)
+		if err != nil {
+			t.Fatalf("DrawProbability() error = %v", err)
+		}
+		if math.Abs(got-tt.exact) > 1e-9 {
+			t.Errorf("DrawProbability(%s, %d) = %v, want %v", tt.want, tt.draws, got, tt.exact)
+		}
+	}
+}
+
+// bruteForceProbability draws every combination of tiles from the pool and counts those containing the wanted tiles.
+func bruteForceProbability(pool string, want string, draws int) float64 {
+	tiles := []rune(pool)
+	matched, total := 0, 0
+	var choose func(start int, drawn []rune)
+	choose = func(start int, drawn []rune) {
+		if len(drawn) == draws {
+			total++
+			if _, ok := takeLetters(drawn, []rune(want)); ok {
+				matched++
+			}
+			return
+		}
+		for i := start; i < len(tiles); i++ {
+			choose(i+1, append(drawn, tiles[i]))
+		}
+	}
+	choose(0, make([]rune, 0, draws))
+	return float64(matched) / float64(total)
+}
+
+func TestProbabilityOfDrawing(t *testing.T) {
+	tests := []struct {
+		name  string
+		pool  string
+		want  string
+		draws int
+		exact float64
+	}{
+		{name: "one of two", pool: "AABB", want: "A", draws: 1, exact: 0.5},
+		{name: "at least one in two draws", pool: "AABB", want: "A", draws: 2, exact: 5.0 / 6},
+		{name: "both copies", pool: "AABB", want: "AA", draws: 2, exact: 1.0 / 6},
+		{name: "two letters", pool: "AABB", want: "AB", draws: 2, exact: 4.0 / 6},
+		{name: "not in pool", pool: "AABB", want: "C", draws: 2, exact: 0},
+		{name: "more than in pool", pool: "AABB", want: "AAA", draws: 4, exact: 0},
+		{name: "nothing wanted", pool: "AABB", want: "", draws: 0, exact: 1},
+		{name: "no draws", pool: "AABB", want: "A", draws: 0, exact: 0},
+		{name: "draws limited to pool", pool: "AABB", want: "AABB", draws: 10, exact: 1},
+		{name: "blanks", pool: "__AEIRST", want: "_S", draws: 3, exact: 11.0 / 56},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := map[rune]int{}
+			for _, l := range tt.pool {
+				pool[l]++
+			}
+			if got := ProbabilityOfDrawing(pool, []rune(tt.want), tt.draws); math.Abs(got-tt.exact) > 1e-9 {
+				t.Errorf("ProbabilityOfDrawing() = %v, want %v", got, tt.exact)
+			}
+		})
+	}
+}
+
+func TestProbabilityOfDrawing_bruteForce(t *testing.T) {
+	const bag = "AAABBCDEE_"
+	pool := map[rune]int{}
+	for _, l := range bag {
+		pool[l]++
+	}
+	for draws := 1; draws <= len(bag); draws++ {
+		for _, want := range []string{"A", "AA", "AAA", "AB", "ABE", "BB", "_", "_AE", "CD", "AAAB"} {
+			got := ProbabilityOfDrawing(pool, []rune(want), draws)
+			if exact := bruteForceProbability(bag, want, draws); math.Abs(got-exact) > 1e-9 {
+				t.Errorf("ProbabilityOfDrawing(%s, %d) = %v, want %v", want, draws, got, exact)
+			}
+		}
+	}
+
+	// a single draw is exactly one letter so the chances of each add up to one
+	sum := 0.0
+	for l := range pool {
+		sum += ProbabilityOfDrawing(pool, []rune{l}, 1)
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("chances of drawing each letter add up to %v", sum)
+	}
+}
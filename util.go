@@ -25,3 +25,11 @@ func dumpCells(cells []Cell) string {
 	}
 	return out
 }
+
+func cellsToWord(cells []Cell) string {
+	out := ""
+	for _, cell := range cells {
+		out = out + cell.String()
+	}
+	return out
+}